@@ -0,0 +1,113 @@
+package tesseract
+
+import (
+	"sort"
+	"unicode"
+)
+
+// CharSet is a sorted set of unique characters, for use with SetWhitelist and SetBlacklist.
+type CharSet []rune
+
+// Predefined character sets. They don't contain the space: tesseract finds spaces between words from the layout,
+// whitelists and blacklists don't affect them.
+var (
+	Digits         = NewCharSet("0123456789")
+	HexDigits      = NewCharSet("0123456789abcdefABCDEF")
+	UpperAlpha     = NewCharSet("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	LowerAlpha     = NewCharSet("abcdefghijklmnopqrstuvwxyz")
+	UpperAlnum     = UpperAlpha.Union(Digits)
+	LowerAlnum     = LowerAlpha.Union(Digits)
+	Alnum          = UpperAlpha.Union(LowerAlpha, Digits)
+	ASCIIPrintable = CharSetFromRange(0x21, 0x7e)
+	Latin1         = ASCIIPrintable.Union(CharSetFromRange(0xa1, 0xff))
+	Greek          = CharSetFromRangeTables(unicode.Greek)
+)
+
+// NewCharSet creates a CharSet with all characters in the given strings.
+// Whitespace and control characters are dropped, tesseract can't recognize them as characters.
+func NewCharSet(chars ...string) CharSet {
+	runes := make([]rune, 0)
+	for _, s := range chars {
+		runes = append(runes, []rune(s)...)
+	}
+	return CharSetFromRunes(runes...)
+}
+
+// CharSetFromRunes creates a CharSet with the given runes.
+// Whitespace and control characters are dropped, tesseract can't recognize them as characters.
+func CharSetFromRunes(runes ...rune) CharSet {
+	set := make(map[rune]struct{}, len(runes))
+	for _, r := range runes {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || r == unicode.ReplacementChar {
+			continue
+		}
+		set[r] = struct{}{}
+	}
+
+	cs := make(CharSet, 0, len(set))
+	for r := range set {
+		cs = append(cs, r)
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i] < cs[j] })
+	return cs
+}
+
+// CharSetFromRange creates a CharSet with all characters from lo up to and including hi. The range is limited to the
+// valid characters, 0 up to unicode.MaxRune. The CharSet is empty when hi is less than lo.
+func CharSetFromRange(lo, hi rune) CharSet {
+	if lo < 0 {
+		lo = 0
+	}
+	if hi > unicode.MaxRune {
+		hi = unicode.MaxRune
+	}
+	if hi < lo {
+		return CharSetFromRunes()
+	}
+	runes := make([]rune, 0, int64(hi)-int64(lo)+1)
+	for r := lo; ; r++ {
+		runes = append(runes, r)
+		if r == hi {
+			break
+		}
+	}
+	return CharSetFromRunes(runes...)
+}
+
+// CharSetFromRangeTables creates a CharSet with all characters in the given tables, e.g. unicode.Greek or unicode.Digit.
+func CharSetFromRangeTables(tables ...*unicode.RangeTable) CharSet {
+	runes := make([]rune, 0)
+	for _, table := range tables {
+		for _, r16 := range table.R16 {
+			for r := rune(r16.Lo); r <= rune(r16.Hi); r += rune(r16.Stride) {
+				runes = append(runes, r)
+			}
+		}
+		for _, r32 := range table.R32 {
+			for r := rune(r32.Lo); r <= rune(r32.Hi); r += rune(r32.Stride) {
+				runes = append(runes, r)
+			}
+		}
+	}
+	return CharSetFromRunes(runes...)
+}
+
+// Union returns a CharSet with the characters of cs and all others.
+func (cs CharSet) Union(others ...CharSet) CharSet {
+	runes := append([]rune{}, cs...)
+	for _, other := range others {
+		runes = append(runes, other...)
+	}
+	return CharSetFromRunes(runes...)
+}
+
+// Contains returns true when r is in the set.
+func (cs CharSet) Contains(r rune) bool {
+	i := sort.Search(len(cs), func(i int) bool { return cs[i] >= r })
+	return i < len(cs) && cs[i] == r
+}
+
+// String returns the characters of the set, as used for the tessedit_char_whitelist and tessedit_char_blacklist variables.
+func (cs CharSet) String() string {
+	return string(cs)
+}
@@ -0,0 +1,57 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import "unicode"
+
+// SetWhitelist makes tesseract only recognize characters from the given sets.
+func (t *Tess) SetWhitelist(sets ...CharSet) error {
+	return t.SetVariable("tessedit_char_whitelist", CharSet{}.Union(sets...).String())
+}
+
+// SetWhitelistString makes tesseract only recognize the characters in chars, see NewCharSet.
+func (t *Tess) SetWhitelistString(chars string) error {
+	return t.SetWhitelist(NewCharSet(chars))
+}
+
+// SetWhitelistRunes makes tesseract only recognize the given characters, see CharSetFromRunes.
+func (t *Tess) SetWhitelistRunes(runes ...rune) error {
+	return t.SetWhitelist(CharSetFromRunes(runes...))
+}
+
+// SetWhitelistRangeTables makes tesseract only recognize the characters in the given tables, see CharSetFromRangeTables.
+func (t *Tess) SetWhitelistRangeTables(tables ...*unicode.RangeTable) error {
+	return t.SetWhitelist(CharSetFromRangeTables(tables...))
+}
+
+// ResetWhitelist removes the whitelist, so tesseract recognizes all characters again.
+func (t *Tess) ResetWhitelist() error {
+	return t.SetVariable("tessedit_char_whitelist", "")
+}
+
+// SetBlacklist makes tesseract never recognize characters from the given sets.
+func (t *Tess) SetBlacklist(sets ...CharSet) error {
+	return t.SetVariable("tessedit_char_blacklist", CharSet{}.Union(sets...).String())
+}
+
+// SetBlacklistString makes tesseract never recognize the characters in chars, see NewCharSet.
+func (t *Tess) SetBlacklistString(chars string) error {
+	return t.SetBlacklist(NewCharSet(chars))
+}
+
+// SetBlacklistRunes makes tesseract never recognize the given characters, see CharSetFromRunes.
+func (t *Tess) SetBlacklistRunes(runes ...rune) error {
+	return t.SetBlacklist(CharSetFromRunes(runes...))
+}
+
+// SetBlacklistRangeTables makes tesseract never recognize the characters in the given tables, see
+// CharSetFromRangeTables.
+func (t *Tess) SetBlacklistRangeTables(tables ...*unicode.RangeTable) error {
+	return t.SetBlacklist(CharSetFromRangeTables(tables...))
+}
+
+// ResetBlacklist removes the blacklist.
+func (t *Tess) ResetBlacklist() error {
+	return t.SetVariable("tessedit_char_blacklist", "")
+}
@@ -0,0 +1,40 @@
+package tesseract
+
+import (
+	"math"
+	"testing"
+	"unicode"
+)
+
+func TestCharSetFromRange(t *testing.T) {
+	tests := []struct {
+		lo, hi   rune
+		expected string
+	}{
+		{'a', 'e', "abcde"},
+		{'a', 'a', "a"},
+		{'e', 'a', ""},
+		// whitespace and control characters are dropped
+		{0x1e, 0x22, "!\""},
+	}
+	for _, test := range tests {
+		cs := CharSetFromRange(test.lo, test.hi)
+		if cs.String() != test.expected {
+			t.Errorf("CharSetFromRange(%q, %q): expected %q, got %q", test.lo, test.hi, test.expected, cs.String())
+		}
+	}
+}
+
+func TestCharSetFromRangeLimits(t *testing.T) {
+	cs := CharSetFromRange(unicode.MaxRune-1, math.MaxInt32)
+	if len(cs) != 2 || cs[0] != unicode.MaxRune-1 || cs[1] != unicode.MaxRune {
+		t.Errorf("expected the last two characters, got %U", cs)
+	}
+	cs = CharSetFromRange(math.MinInt32, 'b')
+	if !cs.Contains('a') || !cs.Contains('b') || cs.Contains('c') {
+		t.Errorf("expected the characters up to b, got %q", cs.String())
+	}
+	if len(CharSetFromRange(math.MaxInt32-1, math.MaxInt32)) != 0 {
+		t.Error("expected no characters beyond unicode.MaxRune")
+	}
+}
@@ -0,0 +1,176 @@
+// Command gotess-eval measures OCR accuracy over a directory of images with ground truth.
+//
+// Every image (png, jpg, tif, bmp, pnm) needs a ground truth file next to it, named like the image with its extension
+// replaced by the -gt suffix (.gt.txt by default), e.g. page1.png and page1.gt.txt. gotess-eval prints the character
+// error rate, word error rate and bag-of-words accuracy for each image and in total, and the most frequent character
+// confusions.
+//
+//	gotess-eval -lang eng -psm PSM_AUTO testdata/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1/eval"
+)
+
+var imageExtensions = map[string]bool{
+	".png":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".tif":  true,
+	".tiff": true,
+	".bmp":  true,
+	".pnm":  true,
+}
+
+var (
+	flagDatapath   = flag.String("datapath", defaultDatapath(), "tessdata directory")
+	flagLanguage   = flag.String("lang", "eng", "language(s) to recognize, e.g. eng+nld")
+	flagPSM        = flag.String("psm", "", "page seg mode, e.g. PSM_AUTO or 3 (default: tesseract's default)")
+	flagGTSuffix   = flag.String("gt", ".gt.txt", "suffix of the ground truth files, replaces the image extension")
+	flagConfusions = flag.Int("confusions", 10, "number of most frequent character confusions to print")
+)
+
+func defaultDatapath() string {
+	prefix := os.Getenv("TESSDATA_PREFIX")
+	if prefix == "" {
+		prefix = "/usr/local/share"
+	}
+	return filepath.Join(prefix, "tessdata")
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] directory...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var psm *tesseract.PageSegMode
+	if *flagPSM != "" {
+		psm = new(tesseract.PageSegMode)
+		err := psm.UnmarshalText([]byte(*flagPSM))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	images, err := findImages(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	t, err := tesseract.NewTess(*flagDatapath, *flagLanguage)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error initializing tesseract: %s\n", err)
+		os.Exit(1)
+	}
+	defer t.Close()
+	if psm != nil {
+		t.SetPageSegMode(*psm)
+	}
+
+	failed := false
+	total := &eval.Result{}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "image\tCER\tWER\tbag of words")
+	for _, image := range images {
+		gtFilename := strings.TrimSuffix(image, filepath.Ext(image)) + *flagGTSuffix
+		groundTruth, err := ioutil.ReadFile(gtFilename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				fmt.Fprintf(os.Stderr, "skipping %s: no ground truth %s\n", image, gtFilename)
+				continue
+			}
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+			continue
+		}
+
+		text, err := recognize(t, image)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", image, err)
+			failed = true
+			continue
+		}
+
+		r := eval.Compare(string(groundTruth), text)
+		total.Add(r)
+		printResult(w, image, r)
+	}
+	printResult(w, "total", total)
+	w.Flush()
+
+	if *flagConfusions > 0 && len(total.Confusions) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "expected\trecognized\tcount")
+		for i, c := range total.Confusions.Sorted() {
+			if i == *flagConfusions {
+				break
+			}
+			fmt.Fprintf(w, "%q\t%q\t%d\n", c.Expected, c.Actual, c.Count)
+		}
+		w.Flush()
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// findImages returns the images in the given directories, sorted by name.
+func findImages(dirs []string) ([]string, error) {
+	images := make([]string, 0)
+	for _, dir := range dirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && imageExtensions[strings.ToLower(filepath.Ext(path))] {
+				images = append(images, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+func recognize(t *tesseract.Tess, filename string) (string, error) {
+	pix, err := leptonica.NewPixFromFile(filename)
+	if err != nil {
+		return "", err
+	}
+	defer pix.Close()
+
+	t.SetImagePix(pix)
+	err = t.Recognize()
+	if err != nil {
+		return "", err
+	}
+	return t.Text(), nil
+}
+
+func printResult(w *tabwriter.Writer, name string, r *eval.Result) {
+	fmt.Fprintf(w, "%s\t%.2f%%\t%.2f%%\t%.2f%%\n", name, 100*r.CER(), 100*r.WER(), 100*r.BagOfWordsAccuracy())
+}
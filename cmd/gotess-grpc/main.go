@@ -0,0 +1,66 @@
+// Command gotess-grpc serves the OCR gRPC service of package tesseractpb with go.tesseract.
+//
+//	gotess-grpc [flags]
+//
+// Each language has a pool of at most -workers tesseract instances.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1/tesseractgrpc"
+	"gopkg.in/GeertJohan/go.tesseract.v1/tesseractpb"
+)
+
+var (
+	flagAddr      = flag.String("addr", ":9090", "address to listen on")
+	flagDatapath  = flag.String("datapath", defaultDatapath(), "tessdata directory")
+	flagLanguages = flag.String("languages", "eng", "comma separated languages that can be requested, e.g. eng,nld,eng+nld")
+	flagWorkers   = flag.Int("workers", runtime.NumCPU(), "maximum number of tesseract instances per language")
+	flagMaxBytes  = flag.Int("max-bytes", 20<<20, "maximum size of a request message in bytes")
+)
+
+func defaultDatapath() string {
+	prefix := os.Getenv("TESSDATA_PREFIX")
+	if prefix == "" {
+		prefix = "/usr/local/share"
+	}
+	return filepath.Join(prefix, "tessdata")
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *flagWorkers < 1 {
+		fmt.Fprintln(os.Stderr, "-workers must be at least 1")
+		os.Exit(2)
+	}
+
+	backend, err := tesseractgrpc.NewPoolBackend(*flagDatapath, strings.Split(*flagLanguages, ","), *flagWorkers)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer backend.Close()
+
+	listener, err := net.Listen("tcp", *flagAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := grpc.NewServer(grpc.MaxRecvMsgSize(*flagMaxBytes))
+	tesseractpb.RegisterOCRServer(server, tesseractgrpc.NewServer(backend))
+	log.Printf("tesseract %s listening on %s", tesseract.Version(), *flagAddr)
+	log.Fatal(server.Serve(listener))
+}
@@ -0,0 +1,335 @@
+// Command gotess-server is an HTTP server that recognizes text in uploaded images with go.tesseract.
+//
+//	gotess-server [flags]
+//
+// Images are posted to /ocr, either as the raw request body or as the "image" field of a multipart form. The query
+// parameters select the language (lang, one of -languages, the first one by default), page seg mode (psm, e.g.
+// PSM_SINGLE_BLOCK or 6) and output format (format: txt, hocr or json). Example:
+//
+//	curl --data-binary @FelixScan.jpg 'http://localhost:8080/ocr?lang=eng&psm=PSM_AUTO&format=json'
+//
+// GET /health reports the tesseract version and the loaded languages.
+//
+// Each language has a pool of at most -workers tesseract instances. A request that doesn't get an instance and finish
+// recognition before the -timeout expires returns 503. The recognition is then stopped, so the instance returns to the
+// pool right away.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+)
+
+var (
+	flagAddr      = flag.String("addr", ":8080", "address to listen on")
+	flagDatapath  = flag.String("datapath", defaultDatapath(), "tessdata directory")
+	flagLanguages = flag.String("languages", "eng", "comma separated languages that can be requested, e.g. eng,nld,eng+nld")
+	flagWorkers   = flag.Int("workers", runtime.NumCPU(), "maximum number of tesseract instances per language")
+	flagMaxBytes  = flag.Int64("max-bytes", 20<<20, "maximum size of an uploaded image in bytes")
+	flagTimeout   = flag.Duration("timeout", time.Minute, "maximum duration of a request, including waiting for an instance")
+)
+
+func defaultDatapath() string {
+	prefix := os.Getenv("TESSDATA_PREFIX")
+	if prefix == "" {
+		prefix = "/usr/local/share"
+	}
+	return filepath.Join(prefix, "tessdata")
+}
+
+// contentTypes maps the output formats to content types
+var contentTypes = map[string]string{
+	"txt":  "text/plain; charset=utf-8",
+	"hocr": "text/html; charset=utf-8",
+	"json": "application/json",
+}
+
+// server serves the ocr and health endpoints. Each language has its own pool, as the language is set when an instance
+// is created.
+type server struct {
+	pools map[string]*tesseract.Pool
+	// defaultLanguage is the first configured language, it's used when a request has no lang
+	defaultLanguage string
+	languages       map[string][]string
+	maxBytes        int64
+	timeout         time.Duration
+}
+
+// document is the json output format
+type document struct {
+	Text       string          `json:"text"`
+	Confidence int             `json:"confidence"`
+	Page       *tesseract.Page `json:"page"`
+}
+
+// health is the response of the health endpoint
+type health struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+	// Languages maps the languages that can be requested to the languages that tesseract loaded for them
+	Languages map[string][]string `json:"languages"`
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *flagWorkers < 1 {
+		fmt.Fprintln(os.Stderr, "-workers must be at least 1")
+		os.Exit(2)
+	}
+
+	s, err := newServer(*flagDatapath, strings.Split(*flagLanguages, ","), *flagWorkers)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer s.close()
+	s.maxBytes = *flagMaxBytes
+	s.timeout = *flagTimeout
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ocr", s.handleOCR)
+	mux.HandleFunc("/health", s.handleHealth)
+	httpServer := &http.Server{
+		Addr:    *flagAddr,
+		Handler: mux,
+		// a slow client can't hold a connection longer than a request may take
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       *flagTimeout,
+	}
+	log.Printf("tesseract %s listening on %s", tesseract.Version(), *flagAddr)
+	log.Fatal(httpServer.ListenAndServe())
+}
+
+// newServer creates a pool for each language. One instance of each pool is created right away, so invalid languages
+// are reported at startup.
+func newServer(datapath string, languages []string, workers int) (*server, error) {
+	s := &server{
+		pools:     make(map[string]*tesseract.Pool),
+		languages: make(map[string][]string),
+	}
+	for _, language := range languages {
+		language = strings.TrimSpace(language)
+		if language == "" || s.pools[language] != nil {
+			continue
+		}
+		lang := language
+		pool := tesseract.NewPool(workers, func() (*tesseract.Tess, error) {
+			return tesseract.NewTess(datapath, lang)
+		})
+		t, err := pool.Get(context.Background())
+		if err != nil {
+			s.close()
+			return nil, fmt.Errorf("error initializing tesseract for %s: %s", language, err)
+		}
+		s.languages[language] = t.LoadedLanguages()
+		pool.Put(t)
+		s.pools[language] = pool
+		if s.defaultLanguage == "" {
+			s.defaultLanguage = language
+		}
+	}
+	if len(s.pools) == 0 {
+		return nil, errors.New("no languages")
+	}
+	return s, nil
+}
+
+func (s *server) close() {
+	for _, pool := range s.pools {
+		pool.Close()
+	}
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, health{
+		Status:    "ok",
+		Version:   tesseract.Version(),
+		Languages: s.languages,
+	})
+}
+
+func (s *server) handleOCR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		w.Header().Set("Allow", "POST, PUT")
+		httpError(w, http.StatusMethodNotAllowed, "use POST or PUT to upload an image")
+		return
+	}
+
+	query := r.URL.Query()
+	lang := query.Get("lang")
+	if lang == "" {
+		lang = s.defaultLanguage
+	}
+	pool, ok := s.pools[lang]
+	if !ok {
+		httpError(w, http.StatusBadRequest, "unsupported language: "+lang)
+		return
+	}
+	var psm *tesseract.PageSegMode
+	if value := query.Get("psm"); value != "" {
+		psm = new(tesseract.PageSegMode)
+		err := psm.UnmarshalText([]byte(value))
+		if err != nil {
+			httpError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	format := strings.ToLower(query.Get("format"))
+	if format == "" {
+		format = "txt"
+	}
+	if _, ok := contentTypes[format]; !ok {
+		httpError(w, http.StatusBadRequest, "unknown output format: "+format)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+
+	data, err := readImage(w, r, s.maxBytes)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			httpError(w, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		httpError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pix, err := leptonica.NewPixReadMem(&data)
+	if err != nil {
+		httpError(w, http.StatusUnsupportedMediaType, "could not read image: "+err.Error())
+		return
+	}
+
+	output, err := recognize(ctx, pool, pix, psm, format)
+	switch {
+	case err == context.DeadlineExceeded:
+		httpError(w, http.StatusServiceUnavailable, "request timed out")
+		return
+	case err == context.Canceled:
+		// the client is gone
+		return
+	case err != nil:
+		httpError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypes[format])
+	io.WriteString(w, output)
+}
+
+// readImage reads the image from the "image" field of a multipart form or from the request body.
+func readImage(w http.ResponseWriter, r *http.Request, maxBytes int64) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 {
+			return nil, errors.New("empty request body")
+		}
+		return data, nil
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errors.New(`missing form field "image"`)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() != "image" {
+			part.Close()
+			continue
+		}
+		defer part.Close()
+		return ioutil.ReadAll(part)
+	}
+}
+
+// recognize recognizes pix with an instance from pool and renders the result in format. The recognition stops when
+// ctx is done, ctx.Err() is then returned.
+func recognize(ctx context.Context, pool *tesseract.Pool, pix *leptonica.Pix, psm *tesseract.PageSegMode, format string) (string, error) {
+	defer pix.Close()
+	var output string
+	err := pool.Document(ctx, func(t *tesseract.Tess) error {
+		if psm != nil {
+			previous := t.PageSegMode()
+			t.SetPageSegMode(*psm)
+			defer t.SetPageSegMode(previous)
+		}
+		t.SetImagePix(pix)
+		err := t.RecognizeContext(ctx)
+		if err != nil {
+			return err
+		}
+		output, err = render(t, format)
+		return err
+	})
+	return output, err
+}
+
+// render returns the recognition results in format.
+func render(t *tesseract.Tess, format string) (string, error) {
+	switch format {
+	case "txt":
+		return t.Text(), nil
+	case "hocr":
+		return t.HOCRText(0), nil
+	}
+
+	page, err := t.Page()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(document{
+		Text:       t.Text(),
+		Confidence: t.MeanTextConfidence(),
+		Page:       page,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+func httpError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentTypes["json"])
+	w.WriteHeader(status)
+	w.Write(data)
+	w.Write([]byte("\n"))
+}
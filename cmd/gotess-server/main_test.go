@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+)
+
+// newTestServer returns a server with only an nld pool of a single instance, which is taken so requests can't get one.
+// The instance is never used, so it doesn't need tesseract.
+func newTestServer(t *testing.T) *server {
+	pool := tesseract.NewPool(1, func() (*tesseract.Tess, error) {
+		return &tesseract.Tess{}, nil
+	})
+	_, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &server{
+		pools:           map[string]*tesseract.Pool{"nld": pool},
+		defaultLanguage: "nld",
+		languages:       map[string][]string{"nld": {"nld"}},
+		maxBytes:        1 << 10,
+		timeout:         50 * time.Millisecond,
+	}
+}
+
+func testPNG(t *testing.T) []byte {
+	buf := &bytes.Buffer{}
+	err := png.Encode(buf, image.NewGray(image.Rect(0, 0, 16, 16)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestHandleOCRErrors(t *testing.T) {
+	s := newTestServer(t)
+	tests := []struct {
+		name   string
+		query  string
+		body   []byte
+		status int
+	}{
+		{"unsupported language", "?lang=eng", []byte("x"), http.StatusBadRequest},
+		{"invalid psm", "?psm=PSM_BOGUS", []byte("x"), http.StatusBadRequest},
+		{"unknown format", "?format=pdf", []byte("x"), http.StatusBadRequest},
+		{"empty body", "", nil, http.StatusBadRequest},
+		{"too large", "", bytes.Repeat([]byte("x"), 2<<10), http.StatusRequestEntityTooLarge},
+		{"not an image", "", []byte("not an image"), http.StatusUnsupportedMediaType},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/ocr"+test.query, bytes.NewReader(test.body))
+		w := httptest.NewRecorder()
+		s.handleOCR(w, r)
+		if w.Code != test.status {
+			t.Errorf("%s: expected status %d, got %d: %s", test.name, test.status, w.Code, w.Body.String())
+		}
+		if !strings.Contains(w.Body.String(), `"error"`) {
+			t.Errorf("%s: expected a json error, got %s", test.name, w.Body.String())
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/ocr", nil)
+	w := httptest.NewRecorder()
+	s.handleOCR(w, r)
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "POST, PUT" {
+		t.Errorf("GET: expected status %d with Allow header, got %d", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestHandleOCRTimeout(t *testing.T) {
+	data := testPNG(t)
+	pix, err := leptonica.NewPixReadMem(&data)
+	if err != nil {
+		t.Skip("leptonica can't read png: ", err)
+	}
+	pix.Close()
+
+	s := newTestServer(t)
+	r := httptest.NewRequest(http.MethodPost, "/ocr", bytes.NewReader(data))
+	w := httptest.NewRecorder()
+	start := time.Now()
+	s.handleOCR(w, r)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusServiceUnavailable, w.Code, w.Body.String())
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the request to time out after %s, took %s", s.timeout, elapsed)
+	}
+}
@@ -0,0 +1,494 @@
+// Command gotess-watch recognizes the images that are dropped in an input directory (a hot folder).
+//
+//	gotess-watch [flags] -in scans -out text
+//
+// The input directory is polled, so it also works for directories shared over the network, where inotify doesn't
+// report files written by other machines. A file is processed when its size and modification time didn't change for
+// the -settle duration, so files that are still being written are skipped, also when the copy keeps the original
+// modification time (cp -p, rsync). Every image is written to the output directory in the -format formats: txt, hocr
+// and pdf. Every page of a multi-page tiff is recognized, the text of the pages is separated by form feeds. Outputs are
+// named after the image without its extension, with a number added when an output with that name exists, so images with
+// the same name don't overwrite each other's outputs.
+//
+// Outputs are written to temporary files. The image is then recorded in a state file, after which the outputs are
+// renamed into place and the image is moved to the done directory, or to the failed directory with the error in a .err
+// file. An image that was processed but not moved before a crash or restart gets its outputs renamed and is moved
+// without recognizing it again, and readers of the output directory never see partial files. Moves use rename, so the
+// done and failed directories must be on the same file system as the input directory.
+//
+// Hidden files (starting with a dot) and directories in the input directory are ignored.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+)
+
+const (
+	exitOK = iota
+	exitFailed
+	exitUsage
+	exitInit
+)
+
+// extensions maps the output formats to file extensions
+var extensions = map[string]string{
+	"txt":  ".txt",
+	"hocr": ".hocr",
+	"pdf":  ".pdf",
+}
+
+var (
+	flagIn       = flag.String("in", "", "input directory to watch")
+	flagOut      = flag.String("out", "", "output directory")
+	flagDone     = flag.String("done", "", "directory for processed images (default: done in the input directory)")
+	flagFailed   = flag.String("failed", "", "directory for images that failed (default: failed in the input directory)")
+	flagState    = flag.String("state", "", "state file (default: .gotess-watch.state in the input directory)")
+	flagLanguage = flag.String("l", "eng", "language(s) to recognize, e.g. eng+nld")
+	flagDatapath = flag.String("datapath", defaultDatapath(), "tessdata directory")
+	flagProfile  = flag.String("profile", "", "profile file (.json, .yaml or .yml) applied to every image")
+	flagFormat   = flag.String("format", "txt", "comma separated output formats: txt, hocr, pdf")
+	flagInterval = flag.Duration("interval", 2*time.Second, "interval between scans of the input directory")
+	flagSettle   = flag.Duration("settle", 5*time.Second, "minimum time that the size and modification time of an image are unchanged")
+	flagOnce     = flag.Bool("once", false, "process the images in the input directory once they settled and exit")
+)
+
+func defaultDatapath() string {
+	prefix := os.Getenv("TESSDATA_PREFIX")
+	if prefix == "" {
+		prefix = "/usr/local/share"
+	}
+	return filepath.Join(prefix, "tessdata")
+}
+
+// watcher processes the images in the input directory
+type watcher struct {
+	t       *tesseract.Tess
+	profile *tesseract.Profile
+	state   *state
+	formats []string
+
+	in, out, done, failed string
+	settle                time.Duration
+	stop                  chan struct{}
+	// pending holds the images that haven't settled by name
+	pending map[string]*pendingImage
+	// compacted is set after the state was compacted for the first time
+	compacted bool
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] -in dir -out dir\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	os.Exit(run())
+}
+
+func run() int {
+	w, err := newWatcher()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		return exitUsage
+	}
+	for _, dir := range []string{w.out, w.done, w.failed} {
+		err = os.MkdirAll(dir, 0755)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitFailed
+		}
+	}
+
+	stateFilename := *flagState
+	if stateFilename == "" {
+		stateFilename = filepath.Join(w.in, ".gotess-watch.state")
+	}
+	w.state, err = openState(stateFilename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading state: %s\n", err)
+		return exitFailed
+	}
+	defer w.state.close()
+
+	w.t, err = w.profile.NewTess(*flagDatapath, *flagLanguage)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error initializing tesseract: %s\n", err)
+		return exitInit
+	}
+	defer w.t.Close()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		log.Print("stopping after the current image")
+		close(w.stop)
+	}()
+
+	ticker := time.NewTicker(*flagInterval)
+	defer ticker.Stop()
+	for {
+		err = w.scan()
+		if err != nil {
+			log.Printf("error scanning %s: %s", w.in, err)
+		}
+		// images that haven't settled yet are processed by a next scan
+		if *flagOnce && (err != nil || len(w.pending) == 0) {
+			if err != nil {
+				return exitFailed
+			}
+			return exitOK
+		}
+		select {
+		case <-ticker.C:
+		case <-w.stop:
+			return exitOK
+		}
+	}
+}
+
+func newWatcher() (*watcher, error) {
+	if *flagIn == "" || *flagOut == "" {
+		return nil, errors.New("-in and -out are required")
+	}
+	w := &watcher{
+		profile: &tesseract.Profile{},
+		in:      *flagIn,
+		out:     *flagOut,
+		done:    *flagDone,
+		failed:  *flagFailed,
+		settle:  *flagSettle,
+		stop:    make(chan struct{}),
+		pending: make(map[string]*pendingImage),
+	}
+	if w.done == "" {
+		w.done = filepath.Join(w.in, "done")
+	}
+	if w.failed == "" {
+		w.failed = filepath.Join(w.in, "failed")
+	}
+
+	if *flagProfile != "" {
+		var err error
+		w.profile, err = tesseract.LoadProfile(*flagProfile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, format := range strings.Split(*flagFormat, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if _, ok := extensions[format]; !ok {
+			return nil, errors.New("unknown output format: " + format)
+		}
+		w.formats = append(w.formats, format)
+	}
+	return w, nil
+}
+
+// pendingImage is the size and modification time of an image that hasn't settled, and since when they are unchanged
+type pendingImage struct {
+	size    int64
+	modTime time.Time
+	since   time.Time
+}
+
+// stopping returns true when the watcher was asked to stop
+func (w *watcher) stopping() bool {
+	select {
+	case <-w.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// scan processes the images in the input directory that have settled, and moves processed images that are still in
+// the input directory.
+func (w *watcher) scan() error {
+	infos, err := ioutil.ReadDir(w.in)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	pending := make(map[string]*pendingImage)
+	for _, info := range infos {
+		if w.stopping() {
+			return nil
+		}
+		if !info.Mode().IsRegular() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		if r := w.state.lookup(info); r != nil {
+			// processed before a restart, but not moved
+			w.move(r)
+			continue
+		}
+		if !w.settled(info, now, pending) {
+			continue
+		}
+		w.process(info)
+	}
+	w.pending = pending
+
+	// records of a previous run are compacted after the first scan moved their images
+	if !w.compacted || w.state.appended >= compactAfter {
+		w.compacted = true
+		return w.compact()
+	}
+	return nil
+}
+
+// settled returns whether the size and modification time of the image didn't change for the settle duration. Images
+// that haven't settled are added to pending.
+func (w *watcher) settled(info os.FileInfo, now time.Time, pending map[string]*pendingImage) bool {
+	p := w.pending[info.Name()]
+	if p == nil || p.size != info.Size() || !p.modTime.Equal(info.ModTime()) {
+		p = &pendingImage{size: info.Size(), modTime: info.ModTime(), since: now}
+	}
+	if now.Sub(p.since) >= w.settle {
+		return true
+	}
+	pending[info.Name()] = p
+	return false
+}
+
+// process recognizes a single image, records it in the state and moves it.
+func (w *watcher) process(info os.FileInfo) {
+	start := time.Now()
+	status := statusDone
+	base, err := w.recognize(info.Name())
+	if err != nil {
+		status = statusFailed
+		log.Printf("%s: %s", info.Name(), err)
+	} else {
+		log.Printf("%s: done in %s", info.Name(), time.Since(start))
+	}
+
+	r := newRecord(info, status, err)
+	r.Output = base
+	err = w.state.add(r)
+	if err != nil {
+		// without a record the image is processed again after a restart, leave it in the input directory
+		log.Printf("%s: error writing state: %s", info.Name(), err)
+		w.removeOutputs(base)
+		return
+	}
+	w.move(r)
+}
+
+// recognize recognizes every page of the image name and writes the outputs to their temporary names, see tmpBase.
+// It returns the base of the outputs, which is empty when recognition failed. The temporary outputs are removed on
+// failure.
+func (w *watcher) recognize(name string) (string, error) {
+	base := w.outputBase(name)
+	tmp := tmpBase(base)
+	err := w.recognizePages(filepath.Join(w.in, name), tmp, filepath.Base(base))
+	if err != nil {
+		w.removeOutputs(base)
+		return "", err
+	}
+	return base, nil
+}
+
+// recognizePages recognizes every page of input and writes the outputs to tmp with the extension of each format.
+// Every page of a multi-page tiff is recognized, the text of the pages is separated by form feeds.
+func (w *watcher) recognizePages(input string, tmp string, title string) error {
+	var pdf *tesseract.PDFRenderer
+	texts := make(map[string]*bytes.Buffer)
+	for _, format := range w.formats {
+		if format != "pdf" {
+			texts[format] = &bytes.Buffer{}
+			continue
+		}
+		var err error
+		pdf, err = w.t.NewPDFRenderer(tmp, title)
+		if err != nil {
+			return err
+		}
+		defer pdf.Close()
+	}
+
+	// shapes learned from one document shouldn't influence the next
+	defer w.t.ClearAdaptiveClassifier()
+	err := tesseract.ReadPages(input, func(n int, pix *leptonica.Pix) error {
+		defer w.t.Clear()
+		w.t.SetImagePix(pix)
+		applied, err := w.profile.Apply(w.t)
+		if err != nil {
+			return err
+		}
+		defer applied.Revert()
+
+		err = w.t.Recognize()
+		if err != nil {
+			return err
+		}
+		if text, ok := texts["txt"]; ok {
+			if n > 0 {
+				text.WriteString("\f")
+			}
+			text.WriteString(w.t.Text())
+		}
+		if hocr, ok := texts["hocr"]; ok {
+			hocr.WriteString(w.t.HOCRText(n))
+		}
+		if pdf != nil {
+			return pdf.AddPage(w.t)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if pdf != nil {
+		err = pdf.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if hocr, ok := texts["hocr"]; ok {
+		texts["hocr"] = bytes.NewBufferString(tesseract.HOCRHeader(title) + hocr.String() + tesseract.HOCRFooter())
+	}
+	for format, text := range texts {
+		err = ioutil.WriteFile(tmp+extensions[format], text.Bytes(), 0644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tmpBase returns the base of the temporary outputs for base. The outputs are written to temporary files that are
+// renamed when the image is recorded in the state, so a crash never leaves a partial set of outputs.
+func tmpBase(base string) string {
+	return filepath.Join(filepath.Dir(base), "."+filepath.Base(base)+".tmp")
+}
+
+// publishOutputs renames the temporary outputs of base into place. Outputs that were renamed before a restart are
+// skipped.
+func (w *watcher) publishOutputs(base string) error {
+	tmp := tmpBase(base)
+	for _, format := range w.formats {
+		err := os.Rename(tmp+extensions[format], base+extensions[format])
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeOutputs removes the temporary outputs of base.
+func (w *watcher) removeOutputs(base string) {
+	if base == "" {
+		return
+	}
+	tmp := tmpBase(base)
+	for _, format := range w.formats {
+		os.Remove(tmp + extensions[format])
+	}
+}
+
+// outputBase returns the path of the outputs of the image name without extension. The name of the image without
+// extension is used, with a number added when an output with that name already exists.
+func (w *watcher) outputBase(name string) string {
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+	base := filepath.Join(w.out, stem)
+	for i := 1; w.outputExists(base); i++ {
+		base = filepath.Join(w.out, stem+"-"+strconv.Itoa(i))
+	}
+	return base
+}
+
+// outputExists returns whether an output of any format exists for base, also a temporary one that wasn't renamed yet.
+func (w *watcher) outputExists(base string) bool {
+	for _, format := range w.formats {
+		for _, filename := range []string{base + extensions[format], tmpBase(base) + extensions[format]} {
+			_, err := os.Lstat(filename)
+			if !os.IsNotExist(err) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// move renames the outputs of r into place and moves the image of r to the done or failed directory. When that fails,
+// the image stays in the input directory and the move is tried again on the next scan.
+func (w *watcher) move(r *record) {
+	if r.Output != "" {
+		err := w.publishOutputs(r.Output)
+		if err != nil {
+			log.Printf("%s: error renaming outputs: %s", r.Name, err)
+			return
+		}
+	}
+	dir := w.done
+	if r.Status == statusFailed {
+		dir = w.failed
+	}
+	target := uniqueName(dir, r.Name)
+	err := os.Rename(filepath.Join(w.in, r.Name), target)
+	if err != nil {
+		log.Printf("%s: error moving to %s: %s", r.Name, dir, err)
+		return
+	}
+	if r.Status == statusFailed {
+		err = writeFile(target+".err", []byte(r.Error+"\n"))
+		if err != nil {
+			log.Printf("%s: error writing error file: %s", r.Name, err)
+		}
+	}
+}
+
+// compact removes the records of images that are no longer in the input directory from the state file.
+func (w *watcher) compact() error {
+	return w.state.compact(func(r *record) bool {
+		info, err := os.Stat(filepath.Join(w.in, r.Name))
+		return err == nil && w.state.lookup(info) == r
+	})
+}
+
+// uniqueName returns the path for name in dir, with a number added when a file with that name already exists.
+func uniqueName(dir string, name string) string {
+	path := filepath.Join(dir, name)
+	ext := filepath.Ext(name)
+	for i := 1; ; i++ {
+		_, err := os.Lstat(path)
+		if os.IsNotExist(err) {
+			return path
+		}
+		path = filepath.Join(dir, strings.TrimSuffix(name, ext)+"-"+strconv.Itoa(i)+ext)
+	}
+}
+
+// writeFile writes data to a temporary file in the directory of filename and renames it to filename.
+func writeFile(filename string, data []byte) error {
+	tmp := filepath.Join(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp")
+	err := ioutil.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp, filename)
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
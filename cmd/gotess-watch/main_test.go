@@ -0,0 +1,117 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSettled(t *testing.T) {
+	dir := t.TempDir()
+	w := &watcher{
+		settle:  5 * time.Second,
+		pending: make(map[string]*pendingImage),
+	}
+	// copies that keep the modification time look old right away
+	modTime := time.Now().Add(-time.Hour)
+	now := time.Now()
+
+	scan := func(size int, modTime time.Time, now time.Time) bool {
+		pending := make(map[string]*pendingImage)
+		settled := w.settled(writeInput(t, dir, "a.png", size, modTime), now, pending)
+		w.pending = pending
+		return settled
+	}
+	if scan(10, modTime, now) {
+		t.Error("expected a new image not to be settled")
+	}
+	if scan(20, modTime, now.Add(4*time.Second)) {
+		t.Error("expected an image that grew not to be settled")
+	}
+	if scan(20, modTime, now.Add(8*time.Second)) {
+		t.Error("expected an image that grew 4 seconds ago not to be settled")
+	}
+	if !scan(20, modTime, now.Add(9*time.Second)) {
+		t.Error("expected an image that didn't change for 5 seconds to be settled")
+	}
+	if len(w.pending) != 0 {
+		t.Errorf("expected no pending images, got %v", w.pending)
+	}
+}
+
+func TestOutputBase(t *testing.T) {
+	dir := t.TempDir()
+	w := &watcher{
+		out:     dir,
+		formats: []string{"txt", "pdf"},
+	}
+	if base := w.outputBase("a.png"); base != filepath.Join(dir, "a") {
+		t.Errorf("expected %s, got %s", filepath.Join(dir, "a"), base)
+	}
+	err := writeFile(filepath.Join(dir, "a.pdf"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base := w.outputBase("a.jpg"); base != filepath.Join(dir, "a-1") {
+		t.Errorf("expected %s, got %s", filepath.Join(dir, "a-1"), base)
+	}
+}
+
+func TestPublishOutputs(t *testing.T) {
+	dir := t.TempDir()
+	w := &watcher{
+		in:      dir,
+		out:     dir,
+		done:    filepath.Join(dir, "done"),
+		formats: []string{"txt", "hocr"},
+	}
+	err := os.Mkdir(w.done, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := filepath.Join(dir, "a")
+	for _, format := range w.formats {
+		err = writeFile(tmpBase(base)+extensions[format], []byte(format))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a temporary output reserves the name, it's renamed after a restart
+	if other := w.outputBase("a.jpg"); other != filepath.Join(dir, "a-1") {
+		t.Errorf("expected %s, got %s", filepath.Join(dir, "a-1"), other)
+	}
+
+	info := writeInput(t, dir, "a.png", 10, time.Now())
+	r := newRecord(info, statusDone, nil)
+	r.Output = base
+	w.move(r)
+	for _, format := range w.formats {
+		data, err := ioutil.ReadFile(base + extensions[format])
+		if err != nil || string(data) != format {
+			t.Errorf("%s: expected the renamed output, got %q, %v", format, data, err)
+		}
+		if _, err := os.Lstat(tmpBase(base) + extensions[format]); !os.IsNotExist(err) {
+			t.Errorf("%s: expected no temporary output, got %v", format, err)
+		}
+	}
+	if _, err := os.Lstat(filepath.Join(w.done, "a.png")); err != nil {
+		t.Errorf("expected the image in the done directory: %s", err)
+	}
+
+	// renaming again after a restart skips the outputs that were renamed
+	if err := w.publishOutputs(base); err != nil {
+		t.Error(err)
+	}
+
+	err = writeFile(tmpBase(base)+".txt", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.removeOutputs(base)
+	if _, err := os.Lstat(tmpBase(base) + ".txt"); !os.IsNotExist(err) {
+		t.Errorf("expected the temporary output to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// compactAfter is the number of records appended to the state file before it is compacted
+const compactAfter = 1000
+
+const (
+	statusDone   = "done"
+	statusFailed = "failed"
+)
+
+// record is a line of the state file. It's written when the temporary outputs of an input are complete, before they
+// are renamed and the input is moved to the done or failed folder. An input that still has a record after a restart was
+// processed, but not moved.
+type record struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	Status  string    `json:"status"`
+	Error   string    `json:"error,omitempty"`
+	// Output is the base of the outputs, without the extension of a format. The outputs have temporary names until
+	// the input is moved.
+	Output string `json:"output,omitempty"`
+}
+
+// key identifies an input by name, size and modification time, so a new file with the name of a processed file is
+// processed again.
+func (r *record) key() string {
+	return r.Name + "\x00" + strconv.FormatInt(r.Size, 10) + "\x00" + strconv.FormatInt(r.ModTime.UnixNano(), 10)
+}
+
+func newRecord(info os.FileInfo, status string, err error) *record {
+	r := &record{
+		Name:    info.Name(),
+		Size:    info.Size(),
+		ModTime: info.ModTime().UTC(),
+		Status:  status,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// state is the journal of processed inputs, stored as json lines.
+type state struct {
+	filename string
+	records  map[string]*record
+	file     *os.File
+	appended int
+}
+
+// openState reads the state file, it's created when it doesn't exist.
+func openState(filename string) (*state, error) {
+	s := &state{
+		filename: filename,
+		records:  make(map[string]*record),
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	// a partially written last line is removed, so new records aren't appended to it. That input is processed again.
+	if end := bytes.LastIndexByte(data, '\n') + 1; end < len(data) {
+		data = data[:end]
+		err = os.Truncate(filename, int64(end))
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		r := &record{}
+		if json.Unmarshal(line, r) == nil {
+			s.records[r.key()] = r
+		}
+	}
+
+	s.file, err = os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// lookup returns the record of the input, or nil when the input wasn't processed.
+func (s *state) lookup(info os.FileInfo) *record {
+	return s.records[newRecord(info, "", nil).key()]
+}
+
+// add appends r to the state file and syncs it to disk.
+func (s *state) add(r *record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = s.file.Write(append(data, '\n'))
+	if err != nil {
+		return err
+	}
+	err = s.file.Sync()
+	if err != nil {
+		return err
+	}
+	s.records[r.key()] = r
+	s.appended++
+	return nil
+}
+
+// compact rewrites the state file with only the records for which keep returns true. The new file replaces the old
+// one with a rename, so a crash leaves either of them.
+func (s *state) compact(keep func(r *record) bool) error {
+	tmp := filepath.Join(filepath.Dir(s.filename), "."+filepath.Base(s.filename)+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	records := make(map[string]*record)
+	for key, r := range s.records {
+		if !keep(r) {
+			continue
+		}
+		data, err := json.Marshal(r)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		w.Write(append(data, '\n'))
+		records[key] = r
+	}
+	err = w.Flush()
+	if err == nil {
+		err = f.Sync()
+	}
+	f.Close()
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	err = os.Rename(tmp, s.filename)
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	file, err := os.OpenFile(s.filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	s.file.Close()
+	s.file = file
+	s.records = records
+	s.appended = 0
+	return nil
+}
+
+func (s *state) close() error {
+	return s.file.Close()
+}
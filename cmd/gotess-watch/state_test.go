@@ -0,0 +1,165 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeInput writes an input file with given size and modification time and returns its info.
+func writeInput(t *testing.T, dir string, name string, size int, modTime time.Time) os.FileInfo {
+	filename := filepath.Join(dir, name)
+	err := ioutil.WriteFile(filename, make([]byte, size), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chtimes(filename, modTime, modTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info
+}
+
+func TestStateLookup(t *testing.T) {
+	dir := t.TempDir()
+	s, err := openState(filepath.Join(dir, "state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.close()
+
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	info := writeInput(t, dir, "a.png", 10, modTime)
+	err = s.add(newRecord(info, statusDone, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := s.lookup(info); r == nil || r.Name != "a.png" || r.Status != statusDone {
+		t.Errorf("expected the record of a.png, got %+v", r)
+	}
+
+	// a new file with the same name is a different input
+	if s.lookup(writeInput(t, dir, "a.png", 11, modTime)) != nil {
+		t.Error("expected no record for a different size")
+	}
+	if s.lookup(writeInput(t, dir, "a.png", 10, modTime.Add(time.Second))) != nil {
+		t.Error("expected no record for a different modification time")
+	}
+	if s.lookup(writeInput(t, dir, "b.png", 10, modTime)) != nil {
+		t.Error("expected no record for a different name")
+	}
+}
+
+func TestOpenStateTruncated(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "state")
+	s, err := openState(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := writeInput(t, dir, "a.png", 10, modTime)
+	b := writeInput(t, dir, "b.png", 20, modTime)
+	err = s.add(newRecord(a, statusDone, nil))
+	if err == nil {
+		err = s.add(newRecord(b, statusFailed, os.ErrInvalid))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.close()
+
+	// a crash while writing the last record leaves a partial line
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filename, data[:len(data)-10], 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = openState(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.close()
+	if s.lookup(a) == nil {
+		t.Error("expected the record of a.png")
+	}
+	if s.lookup(b) != nil {
+		t.Error("expected the partial record of b.png to be ignored")
+	}
+
+	// records are appended after the partial line
+	err = s.add(newRecord(b, statusDone, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.close()
+	s, err = openState(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.lookup(a) == nil || s.lookup(b) == nil {
+		t.Error("expected the records of a.png and b.png")
+	}
+}
+
+func TestStateCompact(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "state")
+	s, err := openState(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.close()
+
+	modTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := writeInput(t, dir, "a.png", 10, modTime)
+	b := writeInput(t, dir, "b.png", 20, modTime)
+	for _, info := range []os.FileInfo{a, b} {
+		err = s.add(newRecord(info, statusDone, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.appended != 2 {
+		t.Errorf("expected 2 appended records, got %d", s.appended)
+	}
+
+	err = s.compact(func(r *record) bool {
+		return r.Name == "b.png"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.lookup(a) != nil || s.lookup(b) == nil || s.appended != 0 {
+		t.Errorf("expected only the record of b.png after compacting, got %v", s.records)
+	}
+
+	// the state file is replaced and new records are appended to it
+	c := writeInput(t, dir, "c.png", 30, modTime)
+	err = s.add(newRecord(c, statusDone, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if strings.Contains(content, "a.png") || !strings.Contains(content, "b.png") || !strings.Contains(content, "c.png") {
+		t.Errorf("unexpected state file:\n%s", content)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".state.tmp")); !os.IsNotExist(err) {
+		t.Error("expected the temporary state file to be removed")
+	}
+}
@@ -0,0 +1,389 @@
+// Command gotess recognizes text in images with go.tesseract.
+//
+//	gotess [flags] image|glob...
+//
+// Every input is written in each of the requested formats: txt, hocr, box, tsv, json and pdf. Without -o the output is
+// written to stdout, pdf files are then written next to the input image. Every page of a multi-page tiff is recognized,
+// the text of the pages is separated by form feeds and hocr has a page div per page. json is always an array with a
+// page per element. Examples:
+//
+//	gotess -l eng+nld -psm PSM_AUTO scans/*.png
+//	gotess -format txt,json -o out -c tessedit_char_whitelist=0123456789 -rect 30,275,1120,1380 FelixScan.jpg
+//
+// Exit codes: 0 when all inputs were recognized, 1 when one or more inputs failed, 2 for invalid usage and 3 when
+// tesseract could not be initialized.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+)
+
+const (
+	exitOK = iota
+	exitFailed
+	exitUsage
+	exitInit
+)
+
+// extensions maps the output formats to file extensions
+var extensions = map[string]string{
+	"txt":  ".txt",
+	"hocr": ".hocr",
+	"box":  ".box",
+	"tsv":  ".tsv",
+	"json": ".json",
+	"pdf":  ".pdf",
+}
+
+// stringsFlag is a flag that can be used multiple times
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+var (
+	flagLanguage = flag.String("l", "eng", "language(s) to recognize, e.g. eng+nld")
+	flagDatapath = flag.String("datapath", defaultDatapath(), "tessdata directory")
+	flagPSM      = flag.String("psm", "", "page seg mode, e.g. PSM_AUTO or 3 (default: tesseract's default)")
+	flagOEM      = flag.String("oem", "OEM_DEFAULT", "engine mode, e.g. OEM_TESSERACT_ONLY or 0")
+	flagRect     = flag.String("rect", "", "recognize only this area: left,top,width,height")
+	flagDPI      = flag.Int("dpi", 0, "resolution of the input images, when not in the image files")
+	flagFormat   = flag.String("format", "txt", "comma separated output formats: txt, hocr, box, tsv, json, pdf")
+	flagOutput   = flag.String("o", "", "output directory, files are named after the input (default: stdout)")
+	flagVars     stringsFlag
+	flagConfigs  stringsFlag
+)
+
+func init() {
+	flag.Var(&flagVars, "c", "set a variable: name=value, can be used multiple times")
+	flag.Var(&flagConfigs, "config", "config file read during initialization, can be used multiple times")
+}
+
+func defaultDatapath() string {
+	prefix := os.Getenv("TESSDATA_PREFIX")
+	if prefix == "" {
+		prefix = "/usr/local/share"
+	}
+	return filepath.Join(prefix, "tessdata")
+}
+
+// options holds the parsed flags
+type options struct {
+	psm     *tesseract.PageSegMode
+	oem     tesseract.EngineMode
+	rect    *tesseract.Rectangle
+	vars    [][2]string
+	formats []string
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] image|glob...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	os.Exit(run())
+}
+
+func run() int {
+	opts, err := parseOptions()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		return exitUsage
+	}
+	inputs, err := expandInputs(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	if *flagOutput != "" {
+		err = os.MkdirAll(*flagOutput, 0755)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitFailed
+		}
+	}
+
+	// like the tesseract command, the variables are set during initialization so that init-only variables work too
+	configs := flagConfigs
+	if len(opts.vars) > 0 {
+		varsConfig, err := writeVarsConfig(opts.vars)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitFailed
+		}
+		defer os.Remove(varsConfig)
+		configs = append(configs[:len(configs):len(configs)], varsConfig)
+	}
+	t, err := tesseract.NewTessWithConfigs(*flagDatapath, *flagLanguage, opts.oem, configs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error initializing tesseract: %s\n", err)
+		return exitInit
+	}
+	defer t.Close()
+
+	// tesseract ignores unknown variables in config files
+	for _, v := range opts.vars {
+		if !t.HasVariable(v[0]) {
+			fmt.Fprintln(os.Stderr, "unknown variable "+v[0])
+			return exitUsage
+		}
+	}
+	if opts.psm != nil {
+		t.SetPageSegMode(*opts.psm)
+	}
+
+	status := exitOK
+	for _, input := range inputs {
+		err = process(t, input, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", input, err)
+			status = exitFailed
+		}
+	}
+	return status
+}
+
+func parseOptions() (*options, error) {
+	opts := &options{}
+	if flag.NArg() == 0 {
+		return nil, errors.New("no input images")
+	}
+
+	if *flagPSM != "" {
+		opts.psm = new(tesseract.PageSegMode)
+		err := opts.psm.UnmarshalText([]byte(*flagPSM))
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := opts.oem.UnmarshalText([]byte(*flagOEM))
+	if err != nil {
+		return nil, err
+	}
+
+	if *flagRect != "" {
+		fields := strings.Split(*flagRect, ",")
+		values := make([]int, 0, 4)
+		for _, f := range fields {
+			v, err := strconv.Atoi(strings.TrimSpace(f))
+			if err != nil {
+				break
+			}
+			values = append(values, v)
+		}
+		if len(fields) != 4 || len(values) != 4 || values[2] <= 0 || values[3] <= 0 {
+			return nil, errors.New("invalid rectangle, expected left,top,width,height: " + *flagRect)
+		}
+		opts.rect = &tesseract.Rectangle{Left: values[0], Top: values[1], Width: values[2], Height: values[3]}
+	}
+
+	for _, v := range flagVars {
+		i := strings.Index(v, "=")
+		if i <= 0 || strings.ContainsAny(v[:i], " \t") {
+			return nil, errors.New("invalid variable, expected name=value: " + v)
+		}
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.New("invalid variable, the value can't contain a newline: " + v)
+		}
+		opts.vars = append(opts.vars, [2]string{v[:i], v[i+1:]})
+	}
+
+	for _, format := range strings.Split(*flagFormat, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if _, ok := extensions[format]; !ok {
+			return nil, errors.New("unknown output format: " + format)
+		}
+		opts.formats = append(opts.formats, format)
+	}
+	return opts, nil
+}
+
+// writeVarsConfig writes the variables to a temporary config file and returns its name.
+func writeVarsConfig(vars [][2]string) (string, error) {
+	f, err := ioutil.TempFile("", "gotess-config")
+	if err != nil {
+		return "", err
+	}
+	for _, v := range vars {
+		_, err = fmt.Fprintf(f, "%s %s\n", v[0], v[1])
+		if err != nil {
+			break
+		}
+	}
+	closeErr := f.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// expandInputs expands the globs in args, arguments without glob characters are used as they are.
+func expandInputs(args []string) ([]string, error) {
+	inputs := make([]string, 0, len(args))
+	for _, arg := range args {
+		if !strings.ContainsAny(arg, "*?[") {
+			inputs = append(inputs, arg)
+			continue
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, errors.New("no files match " + arg)
+		}
+		inputs = append(inputs, matches...)
+	}
+	return inputs, nil
+}
+
+// process recognizes every page of a single input and writes all requested formats.
+func process(t *tesseract.Tess, input string, opts *options) error {
+	base := strings.TrimSuffix(input, filepath.Ext(input))
+	if *flagOutput != "" {
+		base = filepath.Join(*flagOutput, filepath.Base(base))
+	}
+
+	outputs := make(map[string]*bytes.Buffer, len(opts.formats))
+	var pdf *tesseract.PDFRenderer
+	for _, format := range opts.formats {
+		if format != "pdf" {
+			outputs[format] = &bytes.Buffer{}
+			continue
+		}
+		var err error
+		pdf, err = t.NewPDFRenderer(base, filepath.Base(base))
+		if err != nil {
+			return err
+		}
+		defer pdf.Close()
+	}
+
+	var pages []*tesseract.Page
+	err := tesseract.ReadPages(input, func(n int, pix *leptonica.Pix) error {
+		defer t.Clear()
+		t.SetImagePix(pix)
+		if opts.rect != nil {
+			t.SetRectangle(opts.rect.Left, opts.rect.Top, opts.rect.Width, opts.rect.Height)
+		}
+		if *flagDPI > 0 {
+			t.SetSourceResolution(*flagDPI)
+		}
+		err := t.Recognize()
+		if err != nil {
+			return err
+		}
+
+		for format, output := range outputs {
+			if format == "json" {
+				page, err := t.Page()
+				if err != nil {
+					return err
+				}
+				pages = append(pages, page)
+				continue
+			}
+			err = render(t, format, n, output)
+			if err != nil {
+				return err
+			}
+		}
+		if pdf != nil {
+			return pdf.AddPage(t)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if pdf != nil {
+		err = pdf.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if output, ok := outputs["hocr"]; ok {
+		hocr := output.String()
+		output.Reset()
+		output.WriteString(tesseract.HOCRHeader(filepath.Base(base)))
+		output.WriteString(hocr)
+		output.WriteString(tesseract.HOCRFooter())
+	}
+	if output, ok := outputs["json"]; ok {
+		data, err := json.MarshalIndent(pages, "", "  ")
+		if err != nil {
+			return err
+		}
+		output.Write(data)
+		output.WriteString("\n")
+	}
+
+	for _, format := range opts.formats {
+		output, ok := outputs[format]
+		if !ok {
+			continue
+		}
+		if *flagOutput == "" {
+			_, err = os.Stdout.WriteString(output.String())
+		} else {
+			err = ioutil.WriteFile(base+extensions[format], []byte(output.String()), 0644)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render appends the recognition results of page n in a text format to output.
+func render(t *tesseract.Tess, format string, n int, output *bytes.Buffer) error {
+	switch format {
+	case "txt":
+		if n > 0 {
+			output.WriteString("\f")
+		}
+		output.WriteString(t.Text())
+	case "hocr":
+		output.WriteString(t.HOCRText(n))
+	case "box":
+		output.WriteString(t.BoxTextRaw(n))
+	case "tsv":
+		page, err := t.Page()
+		if err != nil {
+			return err
+		}
+		tsv := page.TSV(n)
+		if n > 0 {
+			// the header is written once
+			tsv = tsv[strings.Index(tsv, "\n")+1:]
+		}
+		output.WriteString(tsv)
+	}
+	return nil
+}
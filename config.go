@@ -0,0 +1,88 @@
+package tesseract
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ConfigVariable is a single `name value` line from a tesseract config file.
+type ConfigVariable struct {
+	Name  string
+	Value string
+	// Line is the line number in the config file, starting at 1
+	Line int
+}
+
+// ConfigError is a problem with a single line of a config file.
+type ConfigError struct {
+	Line    int
+	Name    string
+	Message string
+}
+
+// Error implements the error interface
+func (e *ConfigError) Error() string {
+	if e.Name == "" {
+		return "line " + strconv.Itoa(e.Line) + ": " + e.Message
+	}
+	return "line " + strconv.Itoa(e.Line) + ": " + e.Name + ": " + e.Message
+}
+
+// ConfigErrors holds all problems found in a config file.
+type ConfigErrors []*ConfigError
+
+// Error implements the error interface
+func (es ConfigErrors) Error() string {
+	msgs := make([]string, 0, len(es))
+	for _, e := range es {
+		msgs = append(msgs, e.Error())
+	}
+	return "invalid config: " + strings.Join(msgs, "; ")
+}
+
+// ParseConfig parses a tesseract config file the way tesseract reads it. Lines that are empty or start with # are
+// skipped, a # after leading whitespace doesn't start a comment. Every other line is a variable name up to the first
+// space or tab, and a value: the rest of the line after the spaces and tabs that follow the name, trailing whitespace
+// included. A name without value sets the variable to an empty value. Lines that start with whitespace have an empty
+// name, tesseract rejects them. Problems are returned as ConfigErrors, with line numbers.
+func ParseConfig(r io.Reader) ([]ConfigVariable, error) {
+	vars := make([]ConfigVariable, 0)
+	var errs ConfigErrors
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		// trailing whitespace is part of the value, e.g. a space in tessedit_char_blacklist
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value := line, ""
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			name, value = line[:i], strings.TrimLeft(line[i:], " \t")
+		}
+		if name == "" {
+			errs = append(errs, &ConfigError{
+				Line:    lineNumber,
+				Message: "line starts with whitespace, expected `name value`",
+			})
+			continue
+		}
+		vars = append(vars, ConfigVariable{
+			Name:  name,
+			Value: value,
+			Line:  lineNumber,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return vars, nil
+}
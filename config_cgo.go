@@ -0,0 +1,67 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ValidateConfig checks that tesseract knows all variables and that none of them are init-only, because the config is
+// applied after initialization. Problems are returned as ConfigErrors.
+func (t *Tess) ValidateConfig(vars []ConfigVariable) error {
+	var errs ConfigErrors
+	for _, v := range vars {
+		if info, ok := LookupVariable(v.Name); ok && info.InitOnly {
+			errs = append(errs, &ConfigError{
+				Line:    v.Line,
+				Name:    v.Name,
+				Message: "variable can only be set during initialization",
+			})
+			continue
+		}
+		if !t.HasVariable(v.Name) {
+			message := "unknown variable"
+			if suggestion := suggestVariable(v.Name); suggestion != "" {
+				message += ", did you mean " + suggestion + "?"
+			}
+			errs = append(errs, &ConfigError{
+				Line:    v.Line,
+				Name:    v.Name,
+				Message: message,
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// LoadConfigFile parses and validates a config file, and applies it with ReadConfigFile when there are no problems.
+// Unlike ReadConfigFile, filename is not searched in tessdata.
+func (t *Tess) LoadConfigFile(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	vars, err := ParseConfig(file)
+	if err != nil {
+		return err
+	}
+	err = t.ValidateConfig(vars)
+	if err != nil {
+		return err
+	}
+
+	// make sure tesseract doesn't pick a file with the same name from tessdata
+	filename, err = filepath.Abs(filename)
+	if err != nil {
+		return err
+	}
+	t.ReadConfigFile(filename)
+	return nil
+}
@@ -0,0 +1,40 @@
+package tesseract
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	config := "# comment\n" +
+		"\n" +
+		"\r\n" +
+		"tessedit_char_blacklist  |{} \n" +
+		"load_system_dawg\tF\r\n" +
+		"preserve_interword_spaces\n"
+	vars, err := ParseConfig(strings.NewReader(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ConfigVariable{
+		{Name: "tessedit_char_blacklist", Value: "|{} ", Line: 4},
+		{Name: "load_system_dawg", Value: "F", Line: 5},
+		{Name: "preserve_interword_spaces", Value: "", Line: 6},
+	}
+	if len(vars) != len(expected) {
+		t.Fatalf("expected %d variables, got %v", len(expected), vars)
+	}
+	for i, v := range vars {
+		if v != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], v)
+		}
+	}
+
+	// tesseract only skips a # in the first column, and reads lines that start with whitespace as an empty name
+	_, err = ParseConfig(strings.NewReader(config + "  # indented\n  load_freq_dawg F\n \n"))
+	errs, ok := err.(ConfigErrors)
+	if !ok || len(errs) != 3 || errs[0].Line != 7 || errs[1].Line != 8 || errs[2].Line != 9 {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package tesseract
+
+// #include "leptonica/allheaders.h"
+import "C"
+
+import (
+	"errors"
+	"math"
+	"unsafe"
+)
+
+// Deskew estimates the skew of the image set with SetImagePix using TextDirection and rotates the image so the text is level.
+// It returns the angle in radians the image was rotated anti-clockwise.
+// Call Deskew before Recognize. The deskewed image is kept by t until another image is set or t is closed.
+func (t *Tess) Deskew() (float64, error) {
+	if t.pix == nil {
+		return 0, errors.New("no image set")
+	}
+
+	_, slope, ok := t.TextDirection()
+	if !ok {
+		return 0, errors.New("could not estimate text direction")
+	}
+	if slope == 0 {
+		return 0, nil
+	}
+
+	// slope is in image coordinates (y down), so a positive slope means the text is rotated clockwise.
+	// pixRotate rotates clockwise for positive angles.
+	angle := math.Atan(float64(slope))
+	cPix := C.pixRotate((*C.struct_Pix)(unsafe.Pointer(t.pix.CPIX())), C.l_float32(-angle), C.L_ROTATE_AREA_MAP, C.L_BRING_IN_WHITE, 0, 0)
+	if cPix == nil {
+		return 0, errors.New("could not rotate image")
+	}
+	defer destroyPix(cPix)
+	pix, err := newPix(cPix)
+	if err != nil {
+		return 0, err
+	}
+	t.setOwnedImagePix(pix)
+
+	return angle, nil
+}
@@ -0,0 +1,93 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+)
+
+// rotateImage rotates img clockwise by angle radians around its center, uncovered areas are white.
+func rotateImage(img image.Image, angle float64) *image.Gray {
+	bounds := img.Bounds()
+	rotated := image.NewGray(bounds)
+	cx := float64(bounds.Min.X+bounds.Max.X) / 2
+	cy := float64(bounds.Min.Y+bounds.Max.Y) / 2
+	sin, cos := math.Sincos(angle)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			// the source pixel is found by rotating back, anti-clockwise
+			dx, dy := float64(x)-cx, float64(y)-cy
+			sx := int(math.Floor(cx + dx*cos + dy*sin + 0.5))
+			sy := int(math.Floor(cy - dx*sin + dy*cos + 0.5))
+			if !(image.Point{sx, sy}.In(bounds)) {
+				rotated.SetGray(x, y, color.Gray{Y: 0xff})
+				continue
+			}
+			rotated.Set(x, y, img.At(sx, sy))
+		}
+	}
+	return rotated
+}
+
+func TestDeskew(t *testing.T) {
+	tess := newTestTess(t)
+	defer tess.Close()
+
+	f, err := os.Open(filepath.Join("tessexample", "differentFonts.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skew := 3 * math.Pi / 180
+	buf := &bytes.Buffer{}
+	err = png.Encode(buf, rotateImage(img, skew))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	pix, err := leptonica.NewPixReadMem(&data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pix.Close()
+	tess.SetImagePix(pix)
+
+	_, slope, ok := tess.TextDirection()
+	if !ok {
+		t.Fatal("could not estimate text direction of the rotated image")
+	}
+	if measured := math.Atan(float64(slope)); math.Abs(measured-skew) > 1*math.Pi/180 {
+		t.Errorf("expected a skew of %.2f degrees, measured %.2f", skew*180/math.Pi, measured*180/math.Pi)
+	}
+
+	angle, err := tess.Deskew()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(angle-skew) > 1*math.Pi/180 {
+		t.Errorf("expected Deskew to rotate %.2f degrees, rotated %.2f", skew*180/math.Pi, angle*180/math.Pi)
+	}
+
+	_, slope, ok = tess.TextDirection()
+	if !ok {
+		t.Fatal("could not estimate text direction of the deskewed image")
+	}
+	if remaining := math.Atan(float64(slope)); math.Abs(remaining) > 0.5*math.Pi/180 {
+		t.Errorf("expected no skew after Deskew, measured %.2f degrees", remaining*180/math.Pi)
+	}
+}
@@ -0,0 +1,297 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Warning is a known warning that libtesseract writes to stderr while processing an image.
+type Warning int
+
+const (
+	// WarningUnknown is used for diagnostic lines that are not a known warning.
+	WarningUnknown Warning = iota
+	// WarningEmptyPage is reported when tesseract could not find any text on the page ("Empty page!!").
+	WarningEmptyPage
+	// WarningInvalidResolution is reported when the image has no (valid) resolution set ("Warning. Invalid resolution 0 dpi").
+	WarningInvalidResolution
+	// WarningTooFewCharacters is reported when a page has too few characters to be processed ("Too few characters").
+	WarningTooFewCharacters
+)
+
+// String returns a human readable name for the warning
+func (w Warning) String() string {
+	switch w {
+	case WarningEmptyPage:
+		return "empty page"
+	case WarningInvalidResolution:
+		return "invalid resolution"
+	case WarningTooFewCharacters:
+		return "too few characters"
+	}
+	return "unknown"
+}
+
+// parseWarning returns the Warning matching a line of tesseract diagnostic output.
+func parseWarning(line string) Warning {
+	switch {
+	case strings.Contains(line, "Empty page"):
+		return WarningEmptyPage
+	case strings.Contains(line, "Invalid resolution"):
+		return WarningInvalidResolution
+	case strings.Contains(line, "Too few characters"):
+		return WarningTooFewCharacters
+	}
+	return WarningUnknown
+}
+
+// Diagnostic is a single line of diagnostic output written by libtesseract.
+type Diagnostic struct {
+	// Tess is the instance that was processing when the line was written.
+	Tess *Tess
+	// Tag is the tag that was set on Tess with (*Tess).SetDiagnosticsTag, for instance a request id.
+	Tag string
+	// Warning is the known warning this line represents, or WarningUnknown.
+	Warning Warning
+	// Message is the line as written by tesseract, without trailing newline.
+	Message string
+}
+
+// DiagnosticsHandler is called for every line of diagnostic output that could be attributed to a Tess.
+type DiagnosticsHandler func(Diagnostic)
+
+// WriterHandler returns a DiagnosticsHandler that writes each diagnostic as a line to w.
+func WriterHandler(w io.Writer) DiagnosticsHandler {
+	var mu sync.Mutex
+	return func(d Diagnostic) {
+		mu.Lock()
+		defer mu.Unlock()
+		if d.Tag != "" {
+			fmt.Fprintf(w, "tesseract [%s]: %s\n", d.Tag, d.Message)
+			return
+		}
+		fmt.Fprintf(w, "tesseract: %s\n", d.Message)
+	}
+}
+
+// syncMarker is written to the pipe to find out when all output of a call has been read.
+const syncMarker = "\x00go.tesseract-sync"
+
+// diagnostics holds the state of an active stderr capture.
+type diagnostics struct {
+	handler DiagnosticsHandler
+	stderr  *os.File // duplicate of the original stderr
+	r, w    *os.File
+	synced  chan struct{}
+	done    chan struct{}
+
+	// callLock serializes instrumented calls, so output can be attributed to a single Tess
+	callLock sync.Mutex
+	stopped  bool // guarded by callLock
+
+	// current is the Tess running an instrumented call, guarded by currentLock
+	currentLock sync.Mutex
+	current     *Tess
+}
+
+var (
+	diagLock sync.Mutex
+	diag     *diagnostics
+)
+
+// ErrDiagnosticsActive is returned when CaptureDiagnostics is called while already capturing.
+var ErrDiagnosticsActive = errors.New("tesseract diagnostics are already being captured")
+
+// CaptureDiagnostics redirects the C stderr of this process (where libtesseract writes its warnings) into h.
+// Output written while a Tess is recognizing or extracting text is attributed to that Tess and its tag.
+// All other output is passed through to the original stderr.
+//
+// Stderr is a single file descriptor for the whole process, which limits capturing:
+//   - While capturing, recognizing and extracting text is serialized over all Tess instances, so a Pool runs one
+//     recognition at a time. Capture diagnostics for debugging or with a single instance, not for a busy Pool.
+//   - Everything written to stderr during a call is attributed to the Tess making it, also output of Go code (such as
+//     a panic in another goroutine) and of other C libraries.
+//
+// Call StopCapturingDiagnostics to restore stderr.
+func CaptureDiagnostics(h DiagnosticsHandler) error {
+	diagLock.Lock()
+	defer diagLock.Unlock()
+	if diag != nil {
+		return ErrDiagnosticsActive
+	}
+
+	stderrFd, err := dup(int(os.Stderr.Fd()))
+	if err != nil {
+		return err
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		closeFd(stderrFd)
+		return err
+	}
+	err = dup2(int(w.Fd()), int(os.Stderr.Fd()))
+	if err != nil {
+		closeFd(stderrFd)
+		r.Close()
+		w.Close()
+		return err
+	}
+
+	d := &diagnostics{
+		handler: h,
+		stderr:  os.NewFile(uintptr(stderrFd), "stderr"),
+		r:       r,
+		w:       w,
+		synced:  make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go d.read()
+	diag = d
+	return nil
+}
+
+// StopCapturingDiagnostics restores the original stderr after a call to CaptureDiagnostics.
+func StopCapturingDiagnostics() error {
+	diagLock.Lock()
+	defer diagLock.Unlock()
+	if diag == nil {
+		return nil
+	}
+	d := diag
+	diag = nil
+
+	// wait for running calls to finish
+	d.callLock.Lock()
+	defer d.callLock.Unlock()
+	d.stopped = true
+
+	err := dup2(int(d.stderr.Fd()), int(os.Stderr.Fd()))
+	d.w.Close()
+	<-d.done
+	d.r.Close()
+	d.stderr.Close()
+	return err
+}
+
+// read reads lines from the pipe until it is closed. Lines are not limited in length, a long line must not stop the
+// reader, as a full pipe blocks every write to stderr.
+func (d *diagnostics) read() {
+	defer close(d.done)
+	reader := bufio.NewReader(d.r)
+	// sync writes a newline before the marker, so output without a trailing newline is ended before the marker. After
+	// output that did end with a newline this adds an empty line, which is dropped.
+	emptyPending := false
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasSuffix(line, syncMarker):
+			if line != syncMarker {
+				d.handle(strings.TrimSuffix(line, syncMarker))
+			}
+			emptyPending = false
+			d.synced <- struct{}{}
+		case line == "" && err == nil:
+			if emptyPending {
+				d.handle("")
+			}
+			emptyPending = true
+		default:
+			if emptyPending {
+				d.handle("")
+				emptyPending = false
+			}
+			if line != "" {
+				d.handle(line)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// handle passes a line to the handler, or to the original stderr when no Tess is running an instrumented call.
+func (d *diagnostics) handle(line string) {
+	d.currentLock.Lock()
+	t := d.current
+	d.currentLock.Unlock()
+	if t == nil {
+		fmt.Fprintln(d.stderr, line)
+		return
+	}
+
+	warning := parseWarning(line)
+	if warning != WarningUnknown {
+		t.warnings = append(t.warnings, warning)
+	}
+	if d.handler != nil {
+		d.handler(Diagnostic{
+			Tess:    t,
+			Tag:     t.diagnosticsTag,
+			Warning: warning,
+			Message: line,
+		})
+	}
+}
+
+// sync waits until everything written to the pipe before the call has been handled.
+func (d *diagnostics) sync() {
+	// stderr is unbuffered, so everything written before is in the pipe before the marker
+	io.WriteString(d.w, "\n"+syncMarker+"\n")
+	select {
+	case <-d.synced:
+	case <-d.done:
+	}
+}
+
+// capture runs fn and attributes all stderr output written during fn to t.
+func (t *Tess) capture(fn func()) {
+	diagLock.Lock()
+	d := diag
+	diagLock.Unlock()
+	if d == nil {
+		fn()
+		return
+	}
+
+	d.callLock.Lock()
+	defer d.callLock.Unlock()
+	if d.stopped {
+		fn()
+		return
+	}
+
+	d.currentLock.Lock()
+	d.current = t
+	d.currentLock.Unlock()
+
+	fn()
+	d.sync()
+
+	d.currentLock.Lock()
+	d.current = nil
+	d.currentLock.Unlock()
+}
+
+// SetDiagnosticsTag sets a tag that is passed to the DiagnosticsHandler with all output attributed to t.
+// This is typically used to set a request id before processing a request.
+func (t *Tess) SetDiagnosticsTag(tag string) {
+	t.diagnosticsTag = tag
+}
+
+// Warnings returns the known warnings written by tesseract since the last call to SetImagePix or Clear.
+// Warnings are only collected while diagnostics are captured with CaptureDiagnostics.
+func (t *Tess) Warnings() []Warning {
+	warnings := make([]Warning, len(t.warnings))
+	copy(warnings, t.warnings)
+	return warnings
+}
@@ -0,0 +1,96 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestDiagnostics starts reading a pipe like CaptureDiagnostics does, without redirecting stderr.
+// The handled lines of a Tess are returned by the lines function.
+func newTestDiagnostics(t *testing.T) (d *diagnostics, lines func() []string) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var handled []string
+	d = &diagnostics{
+		handler: func(diagnostic Diagnostic) {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, diagnostic.Message)
+		},
+		stderr:  os.Stderr,
+		r:       r,
+		w:       w,
+		synced:  make(chan struct{}),
+		done:    make(chan struct{}),
+		current: &Tess{},
+	}
+	go d.read()
+	t.Cleanup(func() {
+		w.Close()
+		<-d.done
+		r.Close()
+	})
+	return d, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), handled...)
+	}
+}
+
+// syncWithTimeout fails the test when sync doesn't return.
+func syncWithTimeout(t *testing.T, d *diagnostics) {
+	synced := make(chan struct{})
+	go func() {
+		d.sync()
+		close(synced)
+	}()
+	select {
+	case <-synced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sync did not return")
+	}
+}
+
+func TestDiagnosticsWithoutTrailingNewline(t *testing.T) {
+	d, lines := newTestDiagnostics(t)
+
+	io.WriteString(d.w, "Empty page!!\nno newline")
+	syncWithTimeout(t, d)
+	got := lines()
+	if len(got) != 2 || got[0] != "Empty page!!" || got[1] != "no newline" {
+		t.Errorf("unexpected lines: %q", got)
+	}
+	if warnings := d.current.Warnings(); len(warnings) != 1 || warnings[0] != WarningEmptyPage {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	// blank lines written by tesseract are kept, the one added by sync is not
+	io.WriteString(d.w, "one\n\n")
+	syncWithTimeout(t, d)
+	got = lines()[2:]
+	if len(got) != 2 || got[0] != "one" || got[1] != "" {
+		t.Errorf("unexpected lines: %q", got)
+	}
+}
+
+func TestDiagnosticsLongLine(t *testing.T) {
+	d, lines := newTestDiagnostics(t)
+
+	long := strings.Repeat("x", 200*1024)
+	io.WriteString(d.w, long+"\nafter\n")
+	syncWithTimeout(t, d)
+	got := lines()
+	if len(got) != 2 || got[0] != long || got[1] != "after" {
+		t.Errorf("expected the long line and the next line, got %d lines", len(got))
+	}
+}
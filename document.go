@@ -0,0 +1,69 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"errors"
+	"image"
+)
+
+// Page returns the layout of the recognized page with all words.
+// When the image has not been recognized yet, this will run recognition first.
+func (t *Tess) Page() (*Page, error) {
+	bounds, err := t.imageBounds()
+	if err != nil {
+		return nil, err
+	}
+	it, err := t.recognizedIterator()
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{
+		Box:    bounds,
+		Blocks: make([]Block, 0),
+	}
+	for {
+		text, err := it.Text(RIL_WORD)
+		if err != nil {
+			// empty page
+			break
+		}
+
+		if len(page.Blocks) == 0 || it.IsAtBeginningOf(RIL_BLOCK) {
+			box, _ := it.BoundingBox(RIL_BLOCK)
+			page.Blocks = append(page.Blocks, Block{Box: box})
+		}
+		block := &page.Blocks[len(page.Blocks)-1]
+		if len(block.Paragraphs) == 0 || it.IsAtBeginningOf(RIL_PARA) {
+			box, _ := it.BoundingBox(RIL_PARA)
+			block.Paragraphs = append(block.Paragraphs, Paragraph{Box: box})
+		}
+		paragraph := &block.Paragraphs[len(block.Paragraphs)-1]
+		if len(paragraph.Lines) == 0 || it.IsAtBeginningOf(RIL_TEXTLINE) {
+			box, _ := it.BoundingBox(RIL_TEXTLINE)
+			paragraph.Lines = append(paragraph.Lines, Line{Box: box})
+		}
+		line := &paragraph.Lines[len(paragraph.Lines)-1]
+
+		line.Words = append(line.Words, it.word(text))
+
+		if !it.Next(RIL_WORD) {
+			break
+		}
+	}
+	return page, nil
+}
+
+// imageBounds returns the bounds of the image set with SetImagePix or SetImage.
+func (t *Tess) imageBounds() (image.Rectangle, error) {
+	switch {
+	case t.pix != nil:
+		width, height := pixSize(t.pix)
+		return image.Rect(0, 0, width, height), nil
+	case t.img != nil:
+		return image.Rect(0, 0, t.img.Bounds().Dx(), t.img.Bounds().Dy()), nil
+	}
+	return image.Rectangle{}, errors.New("no image set")
+}
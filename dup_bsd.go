@@ -0,0 +1,18 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package tesseract
+
+import "syscall"
+
+func dup(fd int) (int, error) {
+	return syscall.Dup(fd)
+}
+
+func dup2(oldfd, newfd int) error {
+	return syscall.Dup2(oldfd, newfd)
+}
+
+func closeFd(fd int) {
+	syscall.Close(fd)
+}
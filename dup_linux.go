@@ -0,0 +1,16 @@
+package tesseract
+
+import "syscall"
+
+func dup(fd int) (int, error) {
+	return syscall.Dup(fd)
+}
+
+// dup2 uses dup3, dup2 is not available on all linux architectures.
+func dup2(oldfd, newfd int) error {
+	return syscall.Dup3(oldfd, newfd, 0)
+}
+
+func closeFd(fd int) {
+	syscall.Close(fd)
+}
@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package tesseract
+
+import "errors"
+
+var errDupUnsupported = errors.New("capturing tesseract diagnostics is not supported on this platform")
+
+func dup(fd int) (int, error) {
+	return -1, errDupUnsupported
+}
+
+func dup2(oldfd, newfd int) error {
+	return errDupUnsupported
+}
+
+func closeFd(fd int) {}
@@ -0,0 +1,38 @@
+package tesseract
+
+import (
+	"image"
+)
+
+// Engine is the part of Tess that is used to recognize images. Code that depends on Engine instead of *Tess can be
+// tested with the scripted fake in the tesseracttest package, on machines without libtesseract.
+//
+// Engine, the types it uses and the tesseracttest package build with CGO_ENABLED=0. Tess itself requires cgo.
+type Engine interface {
+	// SetImage sets the input image, see (*Tess).SetImage
+	SetImage(img image.Image) error
+	SetRectangle(left, top, width, height int)
+	SetPageSegMode(psm PageSegMode)
+	SetVariable(name, value string) error
+
+	Recognize() error
+	Text() string
+	HOCRText(pagenumber int) string
+	BoxText(pagenumber int) (*BoxText, error)
+	MeanTextConfidence() int
+	Words() ([]Word, error)
+	// Results returns an iterator over the recognition results, see (*Tess).Iterator
+	Results() (Iterator, error)
+
+	Close()
+}
+
+// Iterator iterates over recognition results. It is implemented by *ResultIterator.
+type Iterator interface {
+	Next(level PageIteratorLevel) bool
+	Text(level PageIteratorLevel) (string, error)
+	Confidence(level PageIteratorLevel) float32
+	BoundingBox(level PageIteratorLevel) (rect image.Rectangle, ok bool)
+	WordIsFromDictionary() bool
+	WordIsNumeric() bool
+}
@@ -0,0 +1,115 @@
+// Package eval measures OCR accuracy by comparing recognized text to ground truth.
+package eval
+
+// Operation is an edit operation in an alignment.
+type Operation uint8
+
+const (
+	Match Operation = iota
+	Substitution
+	// Insertion is a token in the actual text that is not in the expected text
+	Insertion
+	// Deletion is a token in the expected text that is missing from the actual text
+	Deletion
+)
+
+// String returns the name of the operation
+func (op Operation) String() string {
+	switch op {
+	case Match:
+		return "match"
+	case Substitution:
+		return "substitution"
+	case Insertion:
+		return "insertion"
+	case Deletion:
+		return "deletion"
+	}
+	return "unknown"
+}
+
+// Edit is a single step of an alignment. Expected is empty for insertions, Actual is empty for deletions.
+type Edit struct {
+	Op       Operation
+	Expected string
+	Actual   string
+}
+
+// Alignment is a list of edits that turns the expected tokens into the actual tokens.
+type Alignment []Edit
+
+// Distance returns the number of edits that are not matches, the edit distance.
+func (a Alignment) Distance() int {
+	distance := 0
+	for _, e := range a {
+		if e.Op != Match {
+			distance++
+		}
+	}
+	return distance
+}
+
+// Align aligns the actual tokens to the expected tokens with a minimal number of edits (Levenshtein distance).
+// It keeps an operation table of len(expected)*len(actual) bytes: aligning two pages of 3000 characters by character
+// takes 9 MB, and the memory grows with the square of the page size.
+func Align(expected, actual []string) Alignment {
+	n, m := len(expected), len(actual)
+
+	// ops holds the last operation of the cheapest alignment of expected[:i] and actual[:j] at i*(m+1)+j.
+	// Only two rows of costs are kept.
+	ops := make([]Operation, (n+1)*(m+1))
+	previous := make([]int, m+1)
+	current := make([]int, m+1)
+	for j := 1; j <= m; j++ {
+		previous[j] = j
+		ops[j] = Insertion
+	}
+	for i := 1; i <= n; i++ {
+		current[0] = i
+		ops[i*(m+1)] = Deletion
+		for j := 1; j <= m; j++ {
+			op, cost := Match, previous[j-1]
+			if expected[i-1] != actual[j-1] {
+				op, cost = Substitution, previous[j-1]+1
+			}
+			if previous[j]+1 < cost {
+				op, cost = Deletion, previous[j]+1
+			}
+			if current[j-1]+1 < cost {
+				op, cost = Insertion, current[j-1]+1
+			}
+			current[j] = cost
+			ops[i*(m+1)+j] = op
+		}
+		previous, current = current, previous
+	}
+
+	// walk back from the end to build the alignment
+	alignment := make(Alignment, 0, maxInt(n, m))
+	i, j := n, m
+	for i > 0 || j > 0 {
+		switch ops[i*(m+1)+j] {
+		case Match, Substitution:
+			alignment = append(alignment, Edit{Op: ops[i*(m+1)+j], Expected: expected[i-1], Actual: actual[j-1]})
+			i--
+			j--
+		case Deletion:
+			alignment = append(alignment, Edit{Op: Deletion, Expected: expected[i-1]})
+			i--
+		case Insertion:
+			alignment = append(alignment, Edit{Op: Insertion, Actual: actual[j-1]})
+			j--
+		}
+	}
+	for l, r := 0, len(alignment)-1; l < r; l, r = l+1, r-1 {
+		alignment[l], alignment[r] = alignment[r], alignment[l]
+	}
+	return alignment
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
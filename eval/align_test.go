@@ -0,0 +1,51 @@
+package eval
+
+import (
+	"strings"
+	"testing"
+)
+
+// format writes an alignment as one character per edit: = match, ~ substitution, + insertion, - deletion.
+func format(a Alignment) string {
+	ops := map[Operation]string{Match: "=", Substitution: "~", Insertion: "+", Deletion: "-"}
+	s := ""
+	for _, e := range a {
+		s += ops[e.Op]
+	}
+	return s
+}
+
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		expected, actual string
+		edits            string
+		distance         int
+	}{
+		{"", "", "", 0},
+		{"abc", "", "---", 3},
+		{"", "abc", "+++", 3},
+		{"abc", "abc", "===", 0},
+		{"abc", "axc", "=~=", 1},
+		{"abc", "ac", "=-=", 1},
+		{"ac", "abc", "=+=", 1},
+		{"kitten", "sitting", "~===~=+", 3},
+	}
+	for _, test := range tests {
+		expected, actual := strings.Split(test.expected, ""), strings.Split(test.actual, "")
+		a := Align(expected, actual)
+		if format(a) != test.edits || a.Distance() != test.distance {
+			t.Errorf("Align(%q, %q): expected %s (%d), got %s (%d)", test.expected, test.actual, test.edits, test.distance, format(a), a.Distance())
+			continue
+		}
+
+		// the edits must turn expected into actual
+		var gotExpected, gotActual string
+		for _, e := range a {
+			gotExpected += e.Expected
+			gotActual += e.Actual
+		}
+		if gotExpected != test.expected || gotActual != test.actual {
+			t.Errorf("Align(%q, %q): edits spell %q and %q", test.expected, test.actual, gotExpected, gotActual)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package eval
+
+import (
+	"sort"
+	"strings"
+)
+
+// Confusion is a substitution of an expected character by another character.
+type Confusion struct {
+	Expected string
+	Actual   string
+}
+
+// Confusions counts how often each confusion occurred, it's a sparse confusion matrix of substituted characters.
+type Confusions map[Confusion]int
+
+// ConfusionCount is a confusion with the number of times it occurred.
+type ConfusionCount struct {
+	Confusion
+	Count int
+}
+
+// Sorted returns the confusions, most frequent first.
+func (cs Confusions) Sorted() []ConfusionCount {
+	counts := make([]ConfusionCount, 0, len(cs))
+	for c, n := range cs {
+		counts = append(counts, ConfusionCount{Confusion: c, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		if counts[i].Expected != counts[j].Expected {
+			return counts[i].Expected < counts[j].Expected
+		}
+		return counts[i].Actual < counts[j].Actual
+	})
+	return counts
+}
+
+// Result holds the accuracy of recognized text compared to ground truth. Results of several pages can be combined with Add.
+type Result struct {
+	// Characters is the number of characters in the ground truth, CharacterErrors the character edit distance
+	Characters      int
+	CharacterErrors int
+	// Words is the number of words in the ground truth, WordErrors the word edit distance
+	Words      int
+	WordErrors int
+	// BagOfWordsMatches is the number of ground truth words found in the recognized text, regardless of order
+	BagOfWordsMatches int
+	Confusions        Confusions
+}
+
+// CER returns the character error rate, the character edit distance divided by the number of ground truth characters.
+// It can be larger than 1 when the recognized text has many extra characters.
+func (r *Result) CER() float64 {
+	return rate(r.CharacterErrors, r.Characters)
+}
+
+// WER returns the word error rate, the word edit distance divided by the number of ground truth words.
+func (r *Result) WER() float64 {
+	return rate(r.WordErrors, r.Words)
+}
+
+// BagOfWordsAccuracy returns the fraction of ground truth words that were recognized, regardless of order.
+// This is a better measure than WER for pages where the reading order of tesseract differs from the ground truth.
+func (r *Result) BagOfWordsAccuracy() float64 {
+	if r.Words == 0 {
+		return 1
+	}
+	return float64(r.BagOfWordsMatches) / float64(r.Words)
+}
+
+func rate(errors, total int) float64 {
+	if total == 0 {
+		if errors == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(errors) / float64(total)
+}
+
+// Add adds the counts of other to r.
+func (r *Result) Add(other *Result) {
+	r.Characters += other.Characters
+	r.CharacterErrors += other.CharacterErrors
+	r.Words += other.Words
+	r.WordErrors += other.WordErrors
+	r.BagOfWordsMatches += other.BagOfWordsMatches
+	if r.Confusions == nil {
+		r.Confusions = make(Confusions)
+	}
+	for c, n := range other.Confusions {
+		r.Confusions[c] += n
+	}
+}
+
+// Words splits text into words at whitespace.
+func Words(text string) []string {
+	return strings.Fields(text)
+}
+
+// Characters splits text into characters, with whitespace normalized to single spaces between words.
+func Characters(text string) []string {
+	normalized := strings.Join(Words(text), " ")
+	chars := make([]string, 0, len(normalized))
+	for _, r := range normalized {
+		chars = append(chars, string(r))
+	}
+	return chars
+}
+
+// Compare compares recognized text to the ground truth. Whitespace is normalized first, so differences in line breaks
+// and indentation don't count as errors.
+func Compare(groundTruth, recognized string) *Result {
+	expectedChars, actualChars := Characters(groundTruth), Characters(recognized)
+	expectedWords, actualWords := Words(groundTruth), Words(recognized)
+
+	r := &Result{
+		Characters: len(expectedChars),
+		Words:      len(expectedWords),
+		Confusions: make(Confusions),
+	}
+
+	for _, e := range Align(expectedChars, actualChars) {
+		if e.Op == Match {
+			continue
+		}
+		r.CharacterErrors++
+		if e.Op == Substitution {
+			r.Confusions[Confusion{Expected: e.Expected, Actual: e.Actual}]++
+		}
+	}
+	r.WordErrors = Align(expectedWords, actualWords).Distance()
+	r.BagOfWordsMatches = bagOfWordsMatches(expectedWords, actualWords)
+	return r
+}
+
+// bagOfWordsMatches counts the expected words that are in actual, each actual word can match once.
+func bagOfWordsMatches(expected, actual []string) int {
+	bag := make(map[string]int, len(actual))
+	for _, w := range actual {
+		bag[w]++
+	}
+	matches := 0
+	for _, w := range expected {
+		if bag[w] > 0 {
+			bag[w]--
+			matches++
+		}
+	}
+	return matches
+}
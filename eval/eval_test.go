@@ -0,0 +1,94 @@
+package eval
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		groundTruth, recognized string
+		cer, wer, bagOfWords    float64
+	}{
+		{"", "", 0, 0, 1},
+		{"", "extra", 1, 1, 1},
+		{"hello world", "", 1, 1, 0},
+		{"hello world", "hello world", 0, 0, 1},
+		// whitespace is normalized
+		{"hello\n  world\n", "hello world", 0, 0, 1},
+		{"hello world", "hallo world", 1.0 / 11, 0.5, 0.5},
+		// the bag of words ignores the reading order
+		{"hello world", "world hello", 8.0 / 11, 1, 1},
+		{"a b", "a b c d", 4.0 / 3, 1, 1},
+	}
+	for _, test := range tests {
+		r := Compare(test.groundTruth, test.recognized)
+		if !near(r.CER(), test.cer) || !near(r.WER(), test.wer) || !near(r.BagOfWordsAccuracy(), test.bagOfWords) {
+			t.Errorf("Compare(%q, %q): expected CER %.3f, WER %.3f, bag of words %.3f, got %.3f, %.3f, %.3f",
+				test.groundTruth, test.recognized, test.cer, test.wer, test.bagOfWords, r.CER(), r.WER(), r.BagOfWordsAccuracy())
+		}
+	}
+}
+
+func TestCompareConfusions(t *testing.T) {
+	r := Compare("l0 0O l", "10 OO 1")
+	sorted := r.Confusions.Sorted()
+	expected := []ConfusionCount{
+		{Confusion{"l", "1"}, 2},
+		{Confusion{"0", "O"}, 1},
+	}
+	if len(sorted) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, sorted)
+	}
+	for i, c := range sorted {
+		if c != expected[i] {
+			t.Errorf("expected %v, got %v", expected, sorted)
+			break
+		}
+	}
+}
+
+func TestConfusionsSorted(t *testing.T) {
+	cs := Confusions{
+		{"e", "c"}: 2,
+		{"l", "1"}: 5,
+		{"e", "a"}: 2,
+		{"O", "0"}: 1,
+	}
+	expected := []ConfusionCount{
+		{Confusion{"l", "1"}, 5},
+		{Confusion{"e", "a"}, 2},
+		{Confusion{"e", "c"}, 2},
+		{Confusion{"O", "0"}, 1},
+	}
+	sorted := cs.Sorted()
+	if len(sorted) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, sorted)
+	}
+	for i, c := range sorted {
+		if c != expected[i] {
+			t.Errorf("expected %v, got %v", expected, sorted)
+			break
+		}
+	}
+	if len(Confusions{}.Sorted()) != 0 {
+		t.Error("expected no confusions")
+	}
+}
+
+func TestResultAdd(t *testing.T) {
+	total := &Result{}
+	total.Add(Compare("hello world", "hallo world"))
+	total.Add(Compare("", ""))
+	total.Add(Compare("foo", "foo"))
+	if total.Characters != 14 || total.CharacterErrors != 1 || total.Words != 3 || total.WordErrors != 1 || total.BagOfWordsMatches != 2 {
+		t.Errorf("unexpected total: %+v", total)
+	}
+	if total.Confusions[Confusion{"e", "a"}] != 1 {
+		t.Errorf("unexpected confusions: %v", total.Confusions)
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
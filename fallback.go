@@ -0,0 +1,159 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"errors"
+	"image"
+	"math"
+	"time"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+)
+
+// Attempt is a set of settings tried by RecognizeWithFallback. Fields that are nil are not used.
+type Attempt struct {
+	Name         string
+	Profile      *Profile
+	Preprocessor Preprocessor
+}
+
+// AttemptResult is the outcome of a single Attempt.
+type AttemptResult struct {
+	Name string
+	// Err is set when the attempt failed, the other fields are empty in that case
+	Err        error
+	Text       string
+	Confidence int
+	// Words are in the coordinates of the input image, also when the preprocessor scaled it
+	Words    []Word
+	Duration time.Duration
+}
+
+// FallbackResult is the result of RecognizeWithFallback.
+type FallbackResult struct {
+	// Best is the attempt with the highest confidence, it's also in Attempts
+	Best *AttemptResult
+	// Attempts has the results of all attempts that were tried, in order
+	Attempts []*AttemptResult
+}
+
+// DefaultFallback returns attempts that help for common problems: the plain image, a single column of text,
+// light text on a dark background, small text and unevenly lit photos.
+func DefaultFallback() []Attempt {
+	singleColumn := PSM_SINGLE_COLUMN
+	return []Attempt{
+		{Name: "default"},
+		{Name: "single column", Profile: &Profile{Name: "single column", PageSegMode: &singleColumn}},
+		{Name: "inverted", Preprocessor: Invert()},
+		{Name: "scaled", Preprocessor: Scale(2)},
+		{Name: "sauvola", Preprocessor: Chain{NormalizeContrast(), SauvolaThreshold(31, 0.35)}},
+	}
+}
+
+// RecognizeWithFallback recognizes pix with each of the attempts in order, until the mean confidence of an attempt is at
+// least threshold (0-100). It returns the best attempt and the results of all attempts that were tried.
+// An error is only returned when all attempts failed. The settings of each attempt are reverted afterwards.
+//
+// The boxes of the words are scaled back to pix when a preprocessor resized the image, other changes of the geometry
+// such as Deskew are not undone. t is left with the image and recognition results of the last attempt that was tried,
+// which is not necessarily the best one: use the results in FallbackResult, not those of t.
+func (t *Tess) RecognizeWithFallback(pix *leptonica.Pix, threshold int, attempts []Attempt) (*FallbackResult, error) {
+	return runAttempts(threshold, attempts, func(attempt Attempt) *AttemptResult {
+		return t.attempt(pix, attempt)
+	})
+}
+
+// runAttempts runs the attempts with run in order, until the confidence of an attempt is at least threshold.
+func runAttempts(threshold int, attempts []Attempt, run func(attempt Attempt) *AttemptResult) (*FallbackResult, error) {
+	if len(attempts) == 0 {
+		return nil, errors.New("no attempts")
+	}
+
+	result := &FallbackResult{
+		Attempts: make([]*AttemptResult, 0, len(attempts)),
+	}
+	for _, attempt := range attempts {
+		ar := run(attempt)
+		result.Attempts = append(result.Attempts, ar)
+		if ar.Err != nil {
+			continue
+		}
+		if result.Best == nil || ar.Confidence > result.Best.Confidence {
+			result.Best = ar
+		}
+		if ar.Confidence >= threshold {
+			break
+		}
+	}
+
+	if result.Best == nil {
+		return result, errors.New("all attempts failed, first error: " + result.Attempts[0].Err.Error())
+	}
+	return result, nil
+}
+
+// attempt recognizes pix with the settings of a single attempt.
+func (t *Tess) attempt(pix *leptonica.Pix, attempt Attempt) *AttemptResult {
+	ar := &AttemptResult{
+		Name: attempt.Name,
+	}
+	start := time.Now()
+	defer func() {
+		ar.Duration = time.Since(start)
+	}()
+
+	if attempt.Preprocessor != nil {
+		ar.Err = t.SetImagePixWith(pix, attempt.Preprocessor)
+		if ar.Err != nil {
+			return ar
+		}
+	} else {
+		t.SetImagePix(pix)
+	}
+
+	if attempt.Profile != nil {
+		applied, err := attempt.Profile.Apply(t)
+		if err != nil {
+			ar.Err = err
+			return ar
+		}
+		defer applied.Revert()
+	}
+
+	ar.Err = t.Recognize()
+	if ar.Err != nil {
+		return ar
+	}
+	words, err := t.Words()
+	if err != nil {
+		ar.Err = err
+		return ar
+	}
+	ar.Text = t.Text()
+	ar.Confidence = t.MeanTextConfidence()
+	ar.Words = words
+	if attempt.Preprocessor != nil {
+		width, height := pixSize(pix)
+		processedWidth, processedHeight := pixSize(t.pix)
+		scaleWords(ar.Words, float64(width)/float64(processedWidth), float64(height)/float64(processedHeight))
+	}
+	return ar
+}
+
+// scaleWords scales the boxes of the words by sx horizontally and sy vertically.
+func scaleWords(words []Word, sx, sy float64) {
+	if sx == 1 && sy == 1 {
+		return
+	}
+	for i := range words {
+		box := words[i].Box
+		words[i].Box = image.Rect(
+			int(math.Floor(float64(box.Min.X)*sx)),
+			int(math.Floor(float64(box.Min.Y)*sy)),
+			int(math.Ceil(float64(box.Max.X)*sx)),
+			int(math.Ceil(float64(box.Max.Y)*sy)),
+		)
+	}
+}
@@ -0,0 +1,84 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"errors"
+	"image"
+	"testing"
+)
+
+// scriptedRun returns a run function for runAttempts that returns the results by attempt name, and records the names.
+func scriptedRun(results map[string]*AttemptResult, names *[]string) func(attempt Attempt) *AttemptResult {
+	return func(attempt Attempt) *AttemptResult {
+		*names = append(*names, attempt.Name)
+		return results[attempt.Name]
+	}
+}
+
+func TestRunAttempts(t *testing.T) {
+	attempts := []Attempt{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+	results := map[string]*AttemptResult{
+		"a": {Name: "a", Confidence: 40},
+		"b": {Name: "b", Err: errors.New("failed")},
+		"c": {Name: "c", Confidence: 85},
+		"d": {Name: "d", Confidence: 95},
+	}
+
+	// the first attempt that reaches the threshold ends the fallback
+	var names []string
+	result, err := runAttempts(80, attempts, scriptedRun(results, &names))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Best != results["c"] || len(result.Attempts) != 3 || len(names) != 3 {
+		t.Errorf("expected c to end the fallback, got best %s after %v", result.Best.Name, names)
+	}
+
+	// without an attempt at the threshold the best one is used
+	names = nil
+	result, err = runAttempts(100, attempts, scriptedRun(results, &names))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Best != results["d"] || len(result.Attempts) != 4 {
+		t.Errorf("expected d as best of all attempts, got %s after %v", result.Best.Name, names)
+	}
+
+	// failed attempts are never the best, even with a higher confidence
+	results["b"].Confidence = 99
+	names = nil
+	result, err = runAttempts(100, attempts[:2], scriptedRun(results, &names))
+	if err != nil || result.Best != results["a"] {
+		t.Errorf("expected a as best, got %v, %v", result.Best, err)
+	}
+}
+
+func TestRunAttemptsFailed(t *testing.T) {
+	attempts := []Attempt{{Name: "a"}, {Name: "b"}}
+	results := map[string]*AttemptResult{
+		"a": {Name: "a", Err: errors.New("first")},
+		"b": {Name: "b", Err: errors.New("second")},
+	}
+	var names []string
+	result, err := runAttempts(50, attempts, scriptedRun(results, &names))
+	if err == nil || err.Error() != "all attempts failed, first error: first" {
+		t.Errorf("expected the first error, got %v", err)
+	}
+	if result == nil || result.Best != nil || len(result.Attempts) != 2 {
+		t.Errorf("expected the results of all failed attempts, got %+v", result)
+	}
+
+	if _, err := runAttempts(50, nil, scriptedRun(results, &names)); err == nil {
+		t.Error("expected an error without attempts")
+	}
+}
+
+func TestScaleWords(t *testing.T) {
+	words := []Word{{Text: "a", Box: image.Rect(10, 21, 31, 40)}}
+	scaleWords(words, 0.5, 0.5)
+	if words[0].Box != image.Rect(5, 10, 16, 20) {
+		t.Errorf("expected the box at half the size, got %v", words[0].Box)
+	}
+}
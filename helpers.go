@@ -15,6 +15,14 @@ func gobool(b C.int) bool {
 	return false
 }
 
+func cbool(b bool) C.int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
 func cStringVectorToStringslice(cStringVector **C.char) []string {
 	// get pointer size to do iteration
 	cPtrSize := unsafe.Sizeof(cStringVector)
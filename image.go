@@ -0,0 +1,151 @@
+package tesseract
+
+// #include "tesseract/capi.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"strconv"
+	"unsafe"
+)
+
+// imageData returns the pixels of img as 8 bit grayscale or 32 bit RGBA, the formats tesseract accepts.
+// Grayscale images are used as they are, all other images are converted to RGBA.
+func imageData(img image.Image) (data []byte, bytesPerPixel int, bytesPerLine int) {
+	switch img := img.(type) {
+	case *image.Gray:
+		return img.Pix, 1, img.Stride
+	case *image.RGBA:
+		return img.Pix, 4, img.Stride
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, img, bounds.Min, draw.Src)
+	return rgba.Pix, 4, rgba.Stride
+}
+
+// void TessBaseAPISetImage(TessBaseAPI* handle, const unsigned char* imagedata, int width, int height, int bytes_per_pixel, int bytes_per_line);
+
+// SetImage sets the input image from a Go image. Tesseract copies the pixels, so img may be changed afterwards.
+// Coordinates used by SetRectangle and returned by the iterators are relative to img.Bounds().Min.
+func (t *Tess) SetImage(img image.Image) error {
+	if img.Bounds().Empty() {
+		return errors.New("empty image")
+	}
+	t.releaseOwnedPix()
+	t.setImage(img)
+	return nil
+}
+
+func (t *Tess) setImage(img image.Image) {
+	bounds := img.Bounds()
+	data, bytesPerPixel, bytesPerLine := imageData(img)
+	C.TessBaseAPISetImage(t.tba, (*C.uchar)(unsafe.Pointer(&data[0])), C.int(bounds.Dx()), C.int(bounds.Dy()), C.int(bytesPerPixel), C.int(bytesPerLine))
+	t.pix = nil
+	t.img = img
+	t.rect = image.Rectangle{}
+	t.warnings = nil
+}
+
+// resetImage sets the current image again, which clears the rectangle, source resolution and recognition results.
+func (t *Tess) resetImage() error {
+	switch {
+	case t.pix != nil:
+		t.setImagePix(t.pix)
+	case t.img != nil:
+		t.setImage(t.img)
+	default:
+		return errors.New("no image set")
+	}
+	return nil
+}
+
+/* char* TessBaseAPIRect(TessBaseAPI* handle, const unsigned char* imagedata, int bytes_per_pixel, int bytes_per_line, int left, int top, int width, int height);
+
+Recognize a rectangle from an image and return the result as a string.
+May be called many times for a single Init.
+Currently has no error checking.
+*/
+
+// RecognizeRect recognizes the area rect of img in one call and returns the text. rect is in the coordinates of img.
+// img stays set as the input image afterwards, as if set with SetImage.
+// To recognize many areas of the same image use RecognizeRegions, which doesn't convert the image for every area.
+func (t *Tess) RecognizeRect(img image.Image, rect image.Rectangle) (string, error) {
+	bounds := img.Bounds()
+	rect = rect.Intersect(bounds)
+	if rect.Empty() {
+		return "", errors.New("rectangle is outside of the image")
+	}
+	rect = rect.Sub(bounds.Min)
+
+	t.releaseOwnedPix()
+	data, bytesPerPixel, bytesPerLine := imageData(img)
+	var cText *C.char
+	t.capture(func() {
+		cText = C.TessBaseAPIRect(t.tba, (*C.uchar)(unsafe.Pointer(&data[0])), C.int(bytesPerPixel), C.int(bytesPerLine),
+			C.int(rect.Min.X), C.int(rect.Min.Y), C.int(rect.Dx()), C.int(rect.Dy()))
+	})
+	t.pix = nil
+	t.img = img
+	t.rect = rect
+	if cText == nil {
+		return "", errors.New("recognition failed")
+	}
+	defer C.free(unsafe.Pointer(cText))
+	return C.GoString(cText), nil
+}
+
+// Region is the recognition result of a single area of an image.
+type Region struct {
+	// Rect is the recognized area in the coordinates of the image, clipped to the image bounds
+	Rect image.Rectangle
+	Text string
+	// Confidence is the mean confidence of the words in the region, between 0 and 100
+	Confidence int
+	// Words are the recognized words, their boxes are in the coordinates of the image
+	Words []Word
+}
+
+// RecognizeRegions sets img as the input image once, and recognizes each of the rects, e.g. the cells of a form.
+// The rects are in the coordinates of img. The rectangle is reset afterwards, img stays set as the input image.
+func (t *Tess) RecognizeRegions(img image.Image, rects []image.Rectangle) ([]Region, error) {
+	err := t.SetImage(img)
+	if err != nil {
+		return nil, err
+	}
+	defer t.resetImage()
+
+	bounds := img.Bounds()
+	regions := make([]Region, 0, len(rects))
+	for i, rect := range rects {
+		rect = rect.Intersect(bounds)
+		if rect.Empty() {
+			return nil, errors.New("region " + strconv.Itoa(i) + " is outside of the image")
+		}
+		r := rect.Sub(bounds.Min)
+		t.SetRectangle(r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+
+		err = t.Recognize()
+		if err != nil {
+			return nil, errors.New("region " + strconv.Itoa(i) + ": " + err.Error())
+		}
+		region := Region{
+			Rect:       rect,
+			Text:       t.Text(),
+			Confidence: t.MeanTextConfidence(),
+		}
+		region.Words, err = t.Words()
+		if err != nil {
+			return nil, errors.New("region " + strconv.Itoa(i) + ": " + err.Error())
+		}
+		// the iterator returns boxes relative to the origin of the image data
+		for j := range region.Words {
+			region.Words[j].Box = region.Words[j].Box.Add(bounds.Min)
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
@@ -0,0 +1,154 @@
+//go:build cgo
+// +build cgo
+
+// Command genvariables generates the registry of tesseract variables in variables_table.go, run it with go generate in
+// the root package.
+//
+// It runs tesseract --print-parameters, asks the tesseract library for the type of every printed parameter, merges
+// the parameters into variables.json and writes the registry from that file. Run it once for every supported
+// tesseract version, oldest first, so Since is the version that introduced a parameter. The tesseract binary and the
+// library that this command is linked with must be the same version. The library is used directly, not through package
+// tesseract, so the registry can be generated when variables_table.go is missing.
+//
+// With -merge=false only the registry is written, e.g. after fixing an InitOnly flag in variables.json.
+package main
+
+// #cgo LDFLAGS: -L /usr/local/lib -ltesseract
+// #include "tesseract/capi.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unsafe"
+)
+
+var (
+	flagTesseract = flag.String("tesseract", "tesseract", "tesseract binary")
+	flagDatapath  = flag.String("datapath", defaultDatapath(), "tessdata directory")
+	flagData      = flag.String("data", filepath.Join("internal", "genvariables", "variables.json"), "data file")
+	flagOut       = flag.String("o", "variables_table.go", "output file")
+	flagMerge     = flag.Bool("merge", true, "merge the parameters of the installed tesseract into the data file")
+)
+
+func defaultDatapath() string {
+	prefix := os.Getenv("TESSDATA_PREFIX")
+	if prefix == "" {
+		prefix = "/usr/local/share"
+	}
+	return filepath.Join(prefix, "tessdata")
+}
+
+func main() {
+	flag.Parse()
+	err := run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "genvariables:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	d, err := readData(*flagData)
+	if err != nil {
+		return err
+	}
+
+	if *flagMerge {
+		version, err := tesseractVersion()
+		if err != nil {
+			return err
+		}
+		if lib := C.GoString(C.TessVersion()); lib != version {
+			return fmt.Errorf("%s is version %s, but linked with %s", *flagTesseract, version, lib)
+		}
+
+		out, err := exec.Command(*flagTesseract, "--print-parameters").Output()
+		if err != nil {
+			return fmt.Errorf("%s --print-parameters: %s", *flagTesseract, err)
+		}
+		printed, err := parseParameters(bytes.NewReader(out))
+		if err != nil {
+			return err
+		}
+
+		handle, err := newHandle(*flagDatapath)
+		if err != nil {
+			return err
+		}
+		defer C.TessBaseAPIDelete(handle)
+		err = d.merge(version, printed, func(name string) (string, bool) {
+			return typeOf(handle, name)
+		})
+		if err != nil {
+			return err
+		}
+		err = d.write(*flagData)
+		if err != nil {
+			return err
+		}
+	}
+
+	src, err := d.generate()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(*flagOut, src, 0644)
+}
+
+// tesseractVersion returns the version printed by tesseract --version, e.g. 5.3.0.
+func tesseractVersion() (string, error) {
+	// tesseract 3.x prints the version to stderr
+	out, err := exec.Command(*flagTesseract, "--version").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s --version: %s", *flagTesseract, err)
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) < 2 || fields[0] != "tesseract" {
+		return "", fmt.Errorf("%s --version: unexpected output %q", *flagTesseract, out)
+	}
+	return strings.TrimPrefix(fields[1], "v"), nil
+}
+
+// newHandle returns an initialized tesseract, the member parameters only exist after initialization.
+func newHandle(datapath string) (*C.TessBaseAPI, error) {
+	cDatapath := C.CString(datapath)
+	defer C.free(unsafe.Pointer(cDatapath))
+	cLanguage := C.CString("eng")
+	defer C.free(unsafe.Pointer(cLanguage))
+
+	handle := C.TessBaseAPICreate()
+	if C.TessBaseAPIInit3(handle, cDatapath, cLanguage) != 0 {
+		C.TessBaseAPIDelete(handle)
+		return nil, errors.New("could not initialize tesseract with " + datapath)
+	}
+	return handle, nil
+}
+
+// typeOf returns the type of the parameter name. tesseract finds a parameter only when asked for its own type.
+func typeOf(handle *C.TessBaseAPI, name string) (string, bool) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cInt C.int
+	var cDouble C.double
+	switch {
+	case C.TessBaseAPIGetIntVariable(handle, cName, &cInt) != 0:
+		return "int", true
+	case C.TessBaseAPIGetBoolVariable(handle, cName, &cInt) != 0:
+		return "bool", true
+	case C.TessBaseAPIGetDoubleVariable(handle, cName, &cDouble) != 0:
+		return "double", true
+	case C.TessBaseAPIGetStringVariable(handle, cName) != nil:
+		return "string", true
+	}
+	return "", false
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// initOnlyNames are the parameters that tesseract only reads while initializing, they're declared with the
+// *_INIT_MEMBER macros in the tesseract sources. tesseract doesn't print this, so new ones must be added here.
+var initOnlyNames = map[string]bool{
+	"language_model_ngram_on":  true,
+	"load_bigram_dawg":         true,
+	"load_fixed_length_dawgs":  true,
+	"load_freq_dawg":           true,
+	"load_number_dawg":         true,
+	"load_punc_dawg":           true,
+	"load_system_dawg":         true,
+	"load_unambig_dawg":        true,
+	"tessedit_load_sublangs":   true,
+	"tessedit_ocr_engine_mode": true,
+	"user_patterns_file":       true,
+	"user_patterns_suffix":     true,
+	"user_words_file":          true,
+	"user_words_suffix":        true,
+}
+
+// types maps the types in the data file to the tesseract.VariableType constants
+var types = map[string]string{
+	"int":    "VariableInt",
+	"bool":   "VariableBool",
+	"double": "VariableDouble",
+	"string": "VariableString",
+}
+
+// parameter is a tesseract parameter as stored in the data file.
+type parameter struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Default     string `json:"default"`
+	InitOnly    bool   `json:"initOnly,omitempty"`
+	Description string `json:"description"`
+	Since       string `json:"since,omitempty"`
+}
+
+// data is the content of the data file.
+type data struct {
+	// Versions are the tesseract versions that were merged, oldest first
+	Versions   []string     `json:"versions"`
+	Parameters []*parameter `json:"parameters"`
+}
+
+// printedParameter is a line of the output of tesseract --print-parameters.
+type printedParameter struct {
+	Name        string
+	Value       string
+	Description string
+}
+
+// parseParameters parses the output of tesseract --print-parameters: a header line followed by a line with the name,
+// value and description of every parameter, separated by tabs.
+func parseParameters(r io.Reader) ([]printedParameter, error) {
+	var printed []printedParameter
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.SplitN(strings.TrimSuffix(scanner.Text(), "\r"), "\t", 3)
+		if len(fields) < 2 || fields[0] == "" {
+			// the header, or a diagnostic written by tesseract
+			continue
+		}
+		p := printedParameter{Name: fields[0], Value: fields[1]}
+		if len(fields) == 3 {
+			p.Description = strings.TrimSpace(fields[2])
+		}
+		printed = append(printed, p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(printed) == 0 {
+		return nil, errors.New("no parameters found")
+	}
+	return printed, nil
+}
+
+// compareVersions compares tesseract versions like 3.05.02 and 5.0.0-alpha by their numeric parts.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var an, bn int
+		if i < len(as) {
+			an = leadingNumber(as[i])
+		}
+		if i < len(bs) {
+			bn = leadingNumber(bs[i])
+		}
+		if an != bn {
+			if an < bn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func leadingNumber(s string) int {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	n, _ := strconv.Atoi(s[:i])
+	return n
+}
+
+// merge adds the parameters printed by tesseract version to d. Versions must be merged oldest first, so Since is the
+// first version that printed a parameter. typeOf returns the type of a parameter, ok is false when it's unknown.
+func (d *data) merge(version string, printed []printedParameter, typeOf func(name string) (string, bool)) error {
+	if n := len(d.Versions); n > 0 && compareVersions(version, d.Versions[n-1]) < 0 {
+		return fmt.Errorf("version %s is older than the last merged version %s, merge versions oldest first", version, d.Versions[n-1])
+	}
+
+	since := version
+	if len(d.Versions) == 0 {
+		since = ""
+	}
+	known := make(map[string]*parameter, len(d.Parameters))
+	for _, p := range d.Parameters {
+		known[p.Name] = p
+	}
+	for _, pp := range printed {
+		if p, ok := known[pp.Name]; ok {
+			if p.Description == "" {
+				p.Description = pp.Description
+			}
+			continue
+		}
+		typ, ok := typeOf(pp.Name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "skipping %s: unknown type\n", pp.Name)
+			continue
+		}
+		p := &parameter{
+			Name:        pp.Name,
+			Type:        typ,
+			Default:     pp.Value,
+			InitOnly:    initOnlyNames[pp.Name],
+			Description: pp.Description,
+			Since:       since,
+		}
+		d.Parameters = append(d.Parameters, p)
+		known[p.Name] = p
+	}
+	sort.Slice(d.Parameters, func(i, j int) bool { return d.Parameters[i].Name < d.Parameters[j].Name })
+
+	if n := len(d.Versions); n == 0 || d.Versions[n-1] != version {
+		d.Versions = append(d.Versions, version)
+	}
+	return nil
+}
+
+// generate returns the go source of the registry.
+func (d *data) generate() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by internal/genvariables from internal/genvariables/variables.json. DO NOT EDIT.\n\n")
+	buf.WriteString("package tesseract\n\n")
+	if len(d.Versions) == 0 {
+		buf.WriteString("// knownVariables holds the tesseract variables, sorted by name.\n")
+	} else {
+		fmt.Fprintf(buf, "// knownVariables holds the variables of tesseract %s, sorted by name.\n", strings.Join(d.Versions, ", "))
+	}
+	buf.WriteString("var knownVariables = []VariableInfo{\n")
+	for _, p := range d.Parameters {
+		typ, ok := types[p.Type]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown type %q", p.Name, p.Type)
+		}
+		fmt.Fprintf(buf, "{%q, %s, %q, %t, %q, %q},\n", p.Name, typ, p.Default, p.InitOnly, p.Description, p.Since)
+	}
+	buf.WriteString("}\n\n")
+	buf.WriteString("// registryVersions are the tesseract versions the registry was generated from, oldest first.\n")
+	buf.WriteString("var registryVersions = []string{")
+	for i, version := range d.Versions {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "%q", version)
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+func readData(filename string) (*data, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	d := &data{}
+	err = json.NewDecoder(f).Decode(d)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return d, nil
+}
+
+func (d *data) write(filename string) error {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "\t")
+	err := encoder.Encode(d)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, buf.Bytes(), 0644)
+}
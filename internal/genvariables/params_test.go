@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseParameters(t *testing.T) {
+	output := "Tesseract parameters:\n" +
+		"tessedit_pageseg_mode\t6\tPage seg mode\n" +
+		"tessedit_char_whitelist\t\tWhitelist of chars to recognize\n" +
+		"textord_min_linesize\t1.25\t* blob height for initial linesize\n"
+	printed, err := parseParameters(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []printedParameter{
+		{"tessedit_pageseg_mode", "6", "Page seg mode"},
+		{"tessedit_char_whitelist", "", "Whitelist of chars to recognize"},
+		{"textord_min_linesize", "1.25", "* blob height for initial linesize"},
+	}
+	if len(printed) != len(expected) {
+		t.Fatalf("expected %d parameters, got %v", len(expected), printed)
+	}
+	for i, p := range printed {
+		if p != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], p)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	typeOf := func(name string) (string, bool) {
+		return "int", name != "unknown"
+	}
+	d := &data{}
+	err := d.merge("4.1.1", []printedParameter{{"b", "1", "B"}, {"unknown", "0", ""}}, typeOf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.merge("5.3.0", []printedParameter{{"b", "2", "B"}, {"a", "0", "A"}}, typeOf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Parameters) != 2 || d.Parameters[0].Name != "a" || d.Parameters[1].Name != "b" {
+		t.Fatalf("unexpected parameters: %v", d.Parameters)
+	}
+	if a := d.Parameters[0]; a.Since != "5.3.0" {
+		t.Errorf("expected a since 5.3.0, got %q", a.Since)
+	}
+	if b := d.Parameters[1]; b.Since != "" || b.Default != "1" {
+		t.Errorf("expected b in all versions with default 1, got %+v", b)
+	}
+
+	if d.merge("5.0.0", nil, typeOf) == nil {
+		t.Error("expected an error merging an older version")
+	}
+
+	source, err := d.generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(source), `var registryVersions = []string{"4.1.1", "5.3.0"}`) {
+		t.Errorf("expected the versions in the generated source:\n%s", source)
+	}
+}
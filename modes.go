@@ -0,0 +1,135 @@
+package tesseract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var pageSegModeNames = []string{
+	PSM_OSD_ONLY:               "PSM_OSD_ONLY",
+	PSM_AUTO_OSD:               "PSM_AUTO_OSD",
+	PSM_AUTO_ONLY:              "PSM_AUTO_ONLY",
+	PSM_AUTO:                   "PSM_AUTO",
+	PSM_SINGLE_COLUMN:          "PSM_SINGLE_COLUMN",
+	PSM_SINGLE_BLOCK_VERT_TEXT: "PSM_SINGLE_BLOCK_VERT_TEXT",
+	PSM_SINGLE_BLOCK:           "PSM_SINGLE_BLOCK",
+	PSM_SINGLE_LINE:            "PSM_SINGLE_LINE",
+	PSM_SINGLE_WORD:            "PSM_SINGLE_WORD",
+	PSM_CIRCLE_WORD:            "PSM_CIRCLE_WORD",
+	PSM_SINGLE_CHAR:            "PSM_SINGLE_CHAR",
+}
+
+// String returns the name of the page seg mode, e.g. "PSM_SINGLE_LINE"
+func (psm PageSegMode) String() string {
+	if psm >= 0 && int(psm) < len(pageSegModeNames) {
+		return pageSegModeNames[psm]
+	}
+	return "PageSegMode(" + strconv.Itoa(int(psm)) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (psm PageSegMode) MarshalText() ([]byte, error) {
+	return []byte(psm.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the name of the mode with or without PSM_ prefix
+// in any case ("PSM_SINGLE_LINE", "single_line") or the number as used by the tesseract command ("7").
+func (psm *PageSegMode) UnmarshalText(text []byte) error {
+	i, err := parseMode(string(text), "PSM_", pageSegModeNames)
+	if err != nil {
+		return errors.New("invalid page seg mode: " + string(text))
+	}
+	*psm = PageSegMode(i)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a string like UnmarshalText, or the number of the mode (7).
+func (psm *PageSegMode) UnmarshalJSON(data []byte) error {
+	text, err := modeText(data)
+	if err != nil || text == nil {
+		return err
+	}
+	return psm.UnmarshalText(text)
+}
+
+var engineModeNames = []string{
+	OEM_TESSERACT_ONLY:          "OEM_TESSERACT_ONLY",
+	OEM_CUBE_ONLY:               "OEM_CUBE_ONLY",
+	OEM_TESSERACT_CUBE_COMBINED: "OEM_TESSERACT_CUBE_COMBINED",
+	OEM_DEFAULT:                 "OEM_DEFAULT",
+}
+
+// String returns the name of the engine mode, e.g. "OEM_DEFAULT"
+func (oem EngineMode) String() string {
+	if oem >= 0 && int(oem) < len(engineModeNames) {
+		return engineModeNames[oem]
+	}
+	return "EngineMode(" + strconv.Itoa(int(oem)) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (oem EngineMode) MarshalText() ([]byte, error) {
+	return []byte(oem.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the name of the mode with or without OEM_ prefix
+// in any case ("OEM_DEFAULT", "default") or the number as used by the tesseract command ("3").
+func (oem *EngineMode) UnmarshalText(text []byte) error {
+	i, err := parseMode(string(text), "OEM_", engineModeNames)
+	if err != nil {
+		return errors.New("invalid engine mode: " + string(text))
+	}
+	*oem = EngineMode(i)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a string like UnmarshalText, or the number of the mode (3).
+func (oem *EngineMode) UnmarshalJSON(data []byte) error {
+	text, err := modeText(data)
+	if err != nil || text == nil {
+		return err
+	}
+	return oem.UnmarshalText(text)
+}
+
+// modeText returns the text of a json string, or the json number as text. It returns nil for null, which leaves the
+// mode unchanged like for other json types.
+func modeText(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil, nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(s), nil
+	}
+	return data, nil
+}
+
+// parseMode finds text in names, or parses text as a number that is a valid index of names.
+func parseMode(text string, prefix string, names []string) (int, error) {
+	if i, err := strconv.Atoi(text); err == nil {
+		if i < 0 || i >= len(names) {
+			return 0, errors.New("out of range")
+		}
+		return i, nil
+	}
+
+	name := strings.ToUpper(text)
+	if !strings.HasPrefix(name, prefix) {
+		name = prefix + name
+	}
+	for i, n := range names {
+		if n == name {
+			return i, nil
+		}
+	}
+	return 0, errors.New("unknown")
+}
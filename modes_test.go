@@ -0,0 +1,52 @@
+package tesseract
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestModesUnmarshalJSON(t *testing.T) {
+	var p Profile
+	err := json.Unmarshal([]byte(`{"pageSegMode": 7, "engineMode": 1}`), &p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.PageSegMode == nil || *p.PageSegMode != PSM_SINGLE_LINE || p.EngineMode == nil || *p.EngineMode != OEM_CUBE_ONLY {
+		t.Errorf("unexpected modes: %v, %v", p.PageSegMode, p.EngineMode)
+	}
+
+	tests := []struct {
+		json     string
+		expected PageSegMode
+		valid    bool
+	}{
+		{`6`, PSM_SINGLE_BLOCK, true},
+		{`"6"`, PSM_SINGLE_BLOCK, true},
+		{`"single_word"`, PSM_SINGLE_WORD, true},
+		{`"PSM_AUTO"`, PSM_AUTO, true},
+		// null leaves the mode unchanged
+		{`null`, PSM_CIRCLE_WORD, true},
+		{`99`, 0, false},
+		{`-1`, 0, false},
+		{`6.5`, 0, false},
+		{`true`, 0, false},
+	}
+	for _, test := range tests {
+		psm := PSM_CIRCLE_WORD
+		err := json.Unmarshal([]byte(test.json), &psm)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %s", test.json, psm)
+			}
+			continue
+		}
+		if err != nil || psm != test.expected {
+			t.Errorf("%s: expected %s, got %s (%v)", test.json, test.expected, psm, err)
+		}
+	}
+
+	var oem EngineMode
+	if err := json.Unmarshal([]byte(`4`), &oem); err == nil {
+		t.Errorf("expected an error for engine mode 4, got %s", oem)
+	}
+}
@@ -0,0 +1,153 @@
+package tesseract
+
+/*
+#include <stdlib.h>
+#include "tesseract/capi.h"
+
+// The monitor functions were added to the C api in tesseract 4.0, and the 4.0 headers don't have a version.h. They are
+// referenced as weak symbols, so the library that is loaded at run time decides whether recognition can be stopped.
+// The names are bound with asm labels, so the declarations don't conflict with those of capi.h when it has them.
+#define GOTESS_STR2(x) #x
+#define GOTESS_STR(x) GOTESS_STR2(x)
+#define GOTESS_SYMBOL(name) GOTESS_STR(__USER_LABEL_PREFIX__) #name
+
+// gotess_cancel_func is TessCancelFunc, which returns BOOL or bool depending on the tesseract version. An int return
+// value works for both.
+typedef int (*gotess_cancel_func)(void* cancel_this, int words);
+
+extern ETEXT_DESC* gotess_monitor_create(void) __asm__(GOTESS_SYMBOL(TessMonitorCreate)) __attribute__((weak));
+extern void gotess_monitor_delete(ETEXT_DESC* monitor) __asm__(GOTESS_SYMBOL(TessMonitorDelete)) __attribute__((weak));
+extern void gotess_monitor_set_cancel_func(ETEXT_DESC* monitor, gotess_cancel_func cancel_func)
+	__asm__(GOTESS_SYMBOL(TessMonitorSetCancelFunc)) __attribute__((weak));
+extern void gotess_monitor_set_cancel_this(ETEXT_DESC* monitor, void* cancel_this)
+	__asm__(GOTESS_SYMBOL(TessMonitorSetCancelThis)) __attribute__((weak));
+extern void gotess_monitor_set_deadline_msecs(ETEXT_DESC* monitor, int deadline)
+	__asm__(GOTESS_SYMBOL(TessMonitorSetDeadlineMSecs)) __attribute__((weak));
+
+// monitor is an ETEXT_DESC with the flag that is checked by its cancel function.
+typedef struct {
+	ETEXT_DESC* desc;
+	int cancelled;
+} monitor;
+
+// monitor_cancelled is the cancel function of a monitor.
+static int monitor_cancelled(void* cancel_this, int words) {
+	return __atomic_load_n(&((monitor*)cancel_this)->cancelled, __ATOMIC_SEQ_CST) != 0;
+}
+
+// monitor_available returns whether the loaded tesseract has the monitor functions.
+static int monitor_available() {
+	return gotess_monitor_create != NULL && gotess_monitor_delete != NULL && gotess_monitor_set_cancel_func != NULL &&
+		gotess_monitor_set_cancel_this != NULL && gotess_monitor_set_deadline_msecs != NULL;
+}
+
+// monitor_create creates a monitor that stops recognition when it is cancelled or after deadline_msecs (when > 0).
+// It returns NULL when tesseract has no monitor functions.
+static monitor* monitor_create(int deadline_msecs) {
+	if (!monitor_available()) {
+		return NULL;
+	}
+	monitor* m = calloc(1, sizeof(monitor));
+	if (m == NULL) {
+		return NULL;
+	}
+	m->desc = gotess_monitor_create();
+	if (m->desc == NULL) {
+		free(m);
+		return NULL;
+	}
+	gotess_monitor_set_cancel_func(m->desc, monitor_cancelled);
+	gotess_monitor_set_cancel_this(m->desc, m);
+	if (deadline_msecs > 0) {
+		gotess_monitor_set_deadline_msecs(m->desc, deadline_msecs);
+	}
+	return m;
+}
+
+static void monitor_cancel(monitor* m) {
+	__atomic_store_n(&m->cancelled, 1, __ATOMIC_SEQ_CST);
+}
+
+static void monitor_delete(monitor* m) {
+	gotess_monitor_delete(m->desc);
+	free(m);
+}
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// ETEXT_DESC* TessMonitorCreate();
+// void TessMonitorDelete(ETEXT_DESC* monitor);
+// void TessMonitorSetCancelFunc(ETEXT_DESC* monitor, TessCancelFunc cancelFunc);
+// void TessMonitorSetCancelThis(ETEXT_DESC* monitor, void* cancelThis);
+// void TessMonitorSetDeadlineMSecs(ETEXT_DESC* monitor, int deadline);
+
+// RecognizeContext is like Recognize, but stops recognition when ctx is done and then returns ctx.Err(). The results
+// of a stopped recognition are incomplete, call Clear or set another image before using the instance again.
+// Tesseract checks for cancellation between words, so RecognizeContext can return a little after ctx is done.
+// Tesseract before 4.0 can't stop recognition, RecognizeContext then returns ctx.Err() after recognition finished.
+func (t *Tess) RecognizeContext(ctx context.Context) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	deadline, hasDeadline := ctx.Deadline()
+	var deadlineMSecs C.int
+	if hasDeadline {
+		msecs := time.Until(deadline) / time.Millisecond
+		if msecs < 1 {
+			msecs = 1
+		} else if msecs > math.MaxInt32 {
+			msecs = math.MaxInt32
+		}
+		deadlineMSecs = C.int(msecs)
+	}
+
+	m := C.monitor_create(deadlineMSecs)
+	if m == nil {
+		err = t.Recognize()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	// cancel the monitor when ctx is done, the goroutine has exited before the monitor is deleted
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			C.monitor_cancel(m)
+		case <-done:
+		}
+	}()
+
+	var ret C.int
+	t.capture(func() {
+		ret = C.TessBaseAPIRecognize(t.tba, m.desc)
+	})
+	close(done)
+	<-exited
+	C.monitor_delete(m)
+
+	if ret != 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// the deadline of the monitor can pass just before the one of ctx
+		if hasDeadline && !time.Now().Before(deadline) {
+			return context.DeadlineExceeded
+		}
+		return errors.New("recognition failed")
+	}
+	return nil
+}
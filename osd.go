@@ -0,0 +1,158 @@
+package tesseract
+
+/*
+#include "leptonica/allheaders.h"
+#include "tesseract/capi.h"
+
+#if defined(__has_include)
+#if __has_include("tesseract/version.h")
+#include "tesseract/version.h"
+#endif
+#endif
+
+// detect_orientation_script uses TessBaseAPIDetectOrientationScript, which is only available since tesseract 4.0.
+// It returns 0 when the detection failed or is not available.
+static int detect_orientation_script(TessBaseAPI* handle, int* orient_deg, float* orient_conf, const char** script_name, float* script_conf) {
+#if defined(TESSERACT_MAJOR_VERSION) && TESSERACT_MAJOR_VERSION >= 4
+	return TessBaseAPIDetectOrientationScript(handle, orient_deg, orient_conf, script_name, script_conf);
+#else
+	return 0;
+#endif
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"unsafe"
+)
+
+// Orientation is the result of orientation and script detection.
+type Orientation struct {
+	// Rotation is the clockwise rotation of the page in degrees: 0, 90, 180 or 270.
+	Rotation int
+	// Confidence is the confidence of the detected rotation.
+	// Tesseract only provides this since version 4.0, with older versions it is always 0.
+	Confidence float32
+	// Script is the detected script (e.g. "Latin"), it is only available since tesseract 4.0.
+	Script string
+	// ScriptConfidence is the confidence of the detected script.
+	ScriptConfidence float32
+
+	PageOrientation  PageOrientation
+	WritingDirection WritingDirection
+	TextlineOrder    TextlineOrder
+	// DeskewAngle is the angle in radians to rotate the upright page anti-clockwise for it to be level.
+	DeskewAngle float32
+}
+
+// rotations maps a PageOrientation to the clockwise rotation of the page.
+var rotations = map[PageOrientation]int{
+	ORIENTATION_PAGE_UP:    0,
+	ORIENTATION_PAGE_RIGHT: 90,
+	ORIENTATION_PAGE_DOWN:  180,
+	ORIENTATION_PAGE_LEFT:  270,
+}
+
+// DetectOrientation detects the orientation and script of the image set with SetImagePix or SetImage.
+// This requires the osd traineddata to be available in the datapath.
+// The page seg mode is temporarily changed to PSM_AUTO_OSD.
+func (t *Tess) DetectOrientation() (Orientation, error) {
+	psm := t.PageSegMode()
+	t.SetPageSegMode(PSM_AUTO_OSD)
+	defer t.SetPageSegMode(psm)
+
+	it, err := t.AnalyseLayout()
+	if err != nil {
+		return Orientation{}, errors.New("could not detect orientation: " + err.Error())
+	}
+	defer it.Close()
+
+	o := Orientation{}
+	o.PageOrientation, o.WritingDirection, o.TextlineOrder, o.DeskewAngle = it.Orientation()
+	o.Rotation = rotations[o.PageOrientation]
+
+	var cDegrees C.int
+	var cConfidence, cScriptConfidence C.float
+	var cScript *C.char
+	var ok C.int
+	t.capture(func() {
+		ok = C.detect_orientation_script(t.tba, &cDegrees, &cConfidence, &cScript, &cScriptConfidence)
+	})
+	if ok != 0 {
+		o.Rotation = int(cDegrees)
+		o.Confidence = float32(cConfidence)
+		o.ScriptConfidence = float32(cScriptConfidence)
+		if cScript != nil {
+			o.Script = C.GoString(cScript)
+		}
+	}
+
+	return o, nil
+}
+
+// RecognizeUpright detects the orientation of the image set with SetImagePix or SetImage, rotates the image upright
+// when required and runs recognition. The rotated image is kept by t until another image is set or t is closed.
+func (t *Tess) RecognizeUpright() (Orientation, error) {
+	if t.pix == nil && t.img == nil {
+		return Orientation{}, errors.New("no image set")
+	}
+
+	o, err := t.DetectOrientation()
+	if err != nil {
+		return o, err
+	}
+
+	if o.Rotation != 0 && t.img != nil {
+		t.setImage(rotateOrth(t.img, 360-o.Rotation))
+	} else if o.Rotation != 0 {
+		// pixRotateOrth rotates clockwise in steps of 90 degrees
+		quads := ((360 - o.Rotation) / 90) % 4
+		cPix := C.pixRotateOrth((*C.struct_Pix)(unsafe.Pointer(t.pix.CPIX())), C.l_int32(quads))
+		if cPix == nil {
+			return o, errors.New("could not rotate image")
+		}
+		defer destroyPix(cPix)
+		pix, err := newPix(cPix)
+		if err != nil {
+			return o, err
+		}
+		t.setOwnedImagePix(pix)
+	}
+
+	return o, t.Recognize()
+}
+
+// rotateOrth returns img rotated clockwise by degrees, which is 90, 180 or 270. The result is grayscale for
+// grayscale images and RGBA otherwise, with its bounds at (0, 0).
+func rotateOrth(img image.Image, degrees int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	rotated := image.Rect(0, 0, w, h)
+	if degrees != 180 {
+		rotated = image.Rect(0, 0, h, w)
+	}
+	var dst draw.Image
+	if _, ok := img.(*image.Gray); ok {
+		dst = image.NewGray(rotated)
+	} else {
+		dst = image.NewRGBA(rotated)
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+			switch degrees {
+			case 90:
+				dst.Set(h-1-y, x, c)
+			case 180:
+				dst.Set(w-1-x, h-1-y, c)
+			case 270:
+				dst.Set(y, w-1-x, c)
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,44 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestRotateOrth(t *testing.T) {
+	// a 3x2 image with a marked top left pixel, at an offset
+	img := image.NewGray(image.Rect(10, 20, 13, 22))
+	img.SetGray(10, 20, color.Gray{Y: 255})
+
+	tests := []struct {
+		degrees int
+		bounds  image.Rectangle
+		marked  image.Point
+	}{
+		{90, image.Rect(0, 0, 2, 3), image.Pt(1, 0)},
+		{180, image.Rect(0, 0, 3, 2), image.Pt(2, 1)},
+		{270, image.Rect(0, 0, 2, 3), image.Pt(0, 2)},
+	}
+	for _, test := range tests {
+		rotated, ok := rotateOrth(img, test.degrees).(*image.Gray)
+		if !ok {
+			t.Errorf("%d: expected a grayscale image", test.degrees)
+			continue
+		}
+		if rotated.Bounds() != test.bounds {
+			t.Errorf("%d: expected bounds %v, got %v", test.degrees, test.bounds, rotated.Bounds())
+			continue
+		}
+		if rotated.GrayAt(test.marked.X, test.marked.Y).Y != 255 {
+			t.Errorf("%d: expected the top left pixel at %v", test.degrees, test.marked)
+		}
+	}
+
+	if _, ok := rotateOrth(image.NewNRGBA(image.Rect(0, 0, 2, 2)), 90).(*image.RGBA); !ok {
+		t.Error("expected an RGBA image for a color image")
+	}
+}
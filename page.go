@@ -0,0 +1,74 @@
+package tesseract
+
+import (
+	"bytes"
+	"image"
+	"strconv"
+	"strings"
+)
+
+// Page is the layout of a recognized page: blocks of paragraphs of lines of words.
+type Page struct {
+	// Box is the area of the image that was recognized
+	Box    image.Rectangle `json:"box"`
+	Blocks []Block         `json:"blocks"`
+}
+
+// Block is a block of text, e.g. a column.
+type Block struct {
+	Box        image.Rectangle `json:"box"`
+	Paragraphs []Paragraph     `json:"paragraphs"`
+}
+
+// Paragraph is a paragraph of text.
+type Paragraph struct {
+	Box   image.Rectangle `json:"box"`
+	Lines []Line          `json:"lines"`
+}
+
+// Line is a single line of text.
+type Line struct {
+	Box   image.Rectangle `json:"box"`
+	Words []Word          `json:"words"`
+}
+
+// TSV returns the page in the tab separated format of the tesseract tsv config, with a header line.
+// Each line is an element with its level (1 page, 2 block, 3 paragraph, 4 line, 5 word), position in the layout,
+// bounding box, confidence (-1 for all levels but words) and text (words only). pagenumber is 0-based.
+func (p *Page) TSV(pagenumber int) string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("level\tpage_num\tblock_num\tpar_num\tline_num\tword_num\tleft\ttop\twidth\theight\tconf\ttext\n")
+	row := func(level, blockNum, parNum, lineNum, wordNum int, box image.Rectangle, conf int, text string) {
+		fields := []string{
+			strconv.Itoa(level),
+			strconv.Itoa(pagenumber + 1),
+			strconv.Itoa(blockNum),
+			strconv.Itoa(parNum),
+			strconv.Itoa(lineNum),
+			strconv.Itoa(wordNum),
+			strconv.Itoa(box.Min.X),
+			strconv.Itoa(box.Min.Y),
+			strconv.Itoa(box.Dx()),
+			strconv.Itoa(box.Dy()),
+			strconv.Itoa(conf),
+			text,
+		}
+		buf.WriteString(strings.Join(fields, "\t"))
+		buf.WriteByte('\n')
+	}
+
+	row(1, 0, 0, 0, 0, p.Box, -1, "")
+	for b, block := range p.Blocks {
+		row(2, b+1, 0, 0, 0, block.Box, -1, "")
+		for pi, paragraph := range block.Paragraphs {
+			row(3, b+1, pi+1, 0, 0, paragraph.Box, -1, "")
+			for l, line := range paragraph.Lines {
+				row(4, b+1, pi+1, l+1, 0, line.Box, -1, "")
+				for w, word := range line.Words {
+					row(5, b+1, pi+1, l+1, w+1, word.Box, word.Confidence, word.Text)
+				}
+			}
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,88 @@
+package tesseract
+
+/*
+#include <stdio.h>
+#include <stdlib.h>
+#include "leptonica/allheaders.h"
+
+// tiff_page_count returns the number of pages of a tiff file, or 0 when filename is not a tiff file.
+static l_int32 tiff_page_count(const char* filename) {
+	l_int32 format = IFF_UNKNOWN;
+	findFileFormat(filename, &format);
+	switch (format) {
+	case IFF_TIFF:
+	case IFF_TIFF_PACKBITS:
+	case IFF_TIFF_RLE:
+	case IFF_TIFF_G3:
+	case IFF_TIFF_G4:
+	case IFF_TIFF_LZW:
+	case IFF_TIFF_ZIP:
+		break;
+	default:
+		return 0;
+	}
+	FILE* fp = fopenReadStream(filename);
+	if (fp == NULL) {
+		return 0;
+	}
+	l_int32 n = 0;
+	tiffGetCount(fp, &n);
+	fclose(fp);
+	return n;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"strconv"
+	"unsafe"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+)
+
+// l_int32 findFileFormat(const char *filename, l_int32 *pformat);
+// l_int32 tiffGetCount(FILE *fp, l_int32 *pn);
+// PIX *pixReadTiff(const char *filename, l_int32 n);
+
+// ReadPages reads the image file filename and calls fn for every page, with the 0-based page number. Every page of a
+// multi-page tiff is read in turn, other files have a single page. The pix is closed when fn returns, so fn must not
+// keep it: call Clear or set another image before returning when it was set with SetImagePix.
+// ReadPages stops at the first error returned by fn.
+func ReadPages(filename string, fn func(page int, pix *leptonica.Pix) error) error {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	n := int(C.tiff_page_count(cFilename))
+	if n <= 1 {
+		pix, err := leptonica.NewPixFromFile(filename)
+		if err != nil {
+			return err
+		}
+		defer pix.Close()
+		return fn(0, pix)
+	}
+
+	for page := 0; page < n; page++ {
+		err := readTiffPage(cFilename, page, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readTiffPage reads a single page of a tiff file and calls fn with it.
+func readTiffPage(cFilename *C.char, page int, fn func(page int, pix *leptonica.Pix) error) error {
+	cPix := C.pixReadTiff(cFilename, C.l_int32(page))
+	if cPix == nil {
+		return errors.New("could not read page " + strconv.Itoa(page+1) + " of " + C.GoString(cFilename))
+	}
+	defer destroyPix(cPix)
+	pix, err := newPix(cPix)
+	if err != nil {
+		return err
+	}
+	defer pix.Close()
+	return fn(page, pix)
+}
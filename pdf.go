@@ -0,0 +1,138 @@
+package tesseract
+
+/*
+#include <stdlib.h>
+#include "tesseract/capi.h"
+
+#if defined(__has_include)
+#if __has_include("tesseract/version.h")
+#include "tesseract/version.h"
+#endif
+#endif
+
+// pdf_renderer_create creates a pdf renderer. Tesseract 3.05 added a textonly argument to TessPDFRendererCreate,
+// it's used for tesseract 4.0 and later. Define GOTESS_PDF_TEXTONLY (CGO_CFLAGS=-DGOTESS_PDF_TEXTONLY) for 3.05.
+static TessResultRenderer* pdf_renderer_create(const char* outputbase, const char* datadir) {
+#if (defined(TESSERACT_MAJOR_VERSION) && TESSERACT_MAJOR_VERSION >= 4) || defined(GOTESS_PDF_TEXTONLY)
+	return TessPDFRendererCreate(outputbase, datadir, 0);
+#else
+	return TessPDFRendererCreate(outputbase, datadir);
+#endif
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// TessResultRenderer* TessPDFRendererCreate(const char* outputbase, const char* datadir);
+// void TessDeleteResultRenderer(TessResultRenderer* renderer);
+// BOOL TessBaseAPIProcessPages(TessBaseAPI* handle, const char* filename, const char* retry_config, int timeout_millisec, TessResultRenderer* renderer);
+
+// RenderPDF recognizes all pages of the image file filename (e.g. a multi page tiff) and writes a searchable pdf to
+// outputbase + ".pdf". The pdf renderer reads its font from the tessdata directory.
+// The image file is read and recognized by tesseract, so settings of the current image like SetRectangle don't apply.
+// Use NewPDFRenderer to write a pdf of images that were recognized already.
+func (t *Tess) RenderPDF(filename string, outputbase string) error {
+	cOutputbase := C.CString(outputbase)
+	defer C.free(unsafe.Pointer(cOutputbase))
+	cDatapath := C.CString(t.datapath)
+	defer C.free(unsafe.Pointer(cDatapath))
+
+	renderer := C.pdf_renderer_create(cOutputbase, cDatapath)
+	if renderer == nil {
+		return errors.New("could not create pdf renderer")
+	}
+	defer C.TessDeleteResultRenderer(renderer)
+
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	var ok C.BOOL
+	t.capture(func() {
+		ok = C.TessBaseAPIProcessPages(t.tba, cFilename, nil, 0, renderer)
+	})
+	if ok == 0 {
+		return errors.New("could not render pdf for " + filename)
+	}
+	return nil
+}
+
+// BOOL TessResultRendererBeginDocument(TessResultRenderer* renderer, const char* title);
+// BOOL TessResultRendererAddImage(TessResultRenderer* renderer, TessBaseAPI* api);
+// BOOL TessResultRendererEndDocument(TessResultRenderer* renderer);
+
+// PDFRenderer writes recognized images as the pages of a searchable pdf, see NewPDFRenderer.
+type PDFRenderer struct {
+	renderer   *C.TessResultRenderer
+	outputbase string
+}
+
+// NewPDFRenderer starts a searchable pdf that is written to outputbase + ".pdf". Add pages with AddPage and finish
+// the pdf with Close. Unlike RenderPDF, the pages are the images recognized by t, with their settings.
+// The pdf renderer reads its font from the tessdata directory of t.
+func (t *Tess) NewPDFRenderer(outputbase string, title string) (*PDFRenderer, error) {
+	cOutputbase := C.CString(outputbase)
+	defer C.free(unsafe.Pointer(cOutputbase))
+	cDatapath := C.CString(t.datapath)
+	defer C.free(unsafe.Pointer(cDatapath))
+
+	renderer := C.pdf_renderer_create(cOutputbase, cDatapath)
+	if renderer == nil {
+		return nil, errors.New("could not create pdf renderer")
+	}
+
+	cTitle := C.CString(title)
+	defer C.free(unsafe.Pointer(cTitle))
+	if C.TessResultRendererBeginDocument(renderer, cTitle) == 0 {
+		C.TessDeleteResultRenderer(renderer)
+		return nil, errors.New("could not create " + outputbase + ".pdf")
+	}
+	return &PDFRenderer{
+		renderer:   renderer,
+		outputbase: outputbase,
+	}, nil
+}
+
+// AddPage adds the current image of t as a page, with the text of the recognition results. The image is recognized
+// first when that wasn't done yet. The page size follows from the image size and its resolution, see
+// SetSourceResolution. Tesseract before 4.0 writes the page image from the input file, which isn't set here, so use
+// RenderPDF with those versions.
+func (r *PDFRenderer) AddPage(t *Tess) error {
+	if r.renderer == nil {
+		return errors.New("pdf renderer is closed")
+	}
+	if t.pix == nil && t.img == nil {
+		return errors.New("no image set")
+	}
+	// the renderer walks the results, which tesseract doesn't create on demand
+	_, err := t.recognizedIterator()
+	if err != nil {
+		return err
+	}
+
+	var ok C.BOOL
+	t.capture(func() {
+		ok = C.TessResultRendererAddImage(r.renderer, t.tba)
+	})
+	if ok == 0 {
+		return errors.New("could not add page to " + r.outputbase + ".pdf")
+	}
+	return nil
+}
+
+// Close finishes the pdf and releases the renderer.
+func (r *PDFRenderer) Close() error {
+	if r.renderer == nil {
+		return nil
+	}
+	ok := C.TessResultRendererEndDocument(r.renderer)
+	C.TessDeleteResultRenderer(r.renderer)
+	r.renderer = nil
+	if ok == 0 {
+		return errors.New("could not write " + r.outputbase + ".pdf")
+	}
+	return nil
+}
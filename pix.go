@@ -0,0 +1,121 @@
+package tesseract
+
+// #cgo LDFLAGS: -llept
+// #include "leptonica/allheaders.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"errors"
+	"image"
+	"unsafe"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+)
+
+// newPix creates a leptonica.Pix from a C PIX that was created by tesseract or leptonica.
+// go.leptonica has no constructor for an existing PIX, so the image is copied through memory as png.
+// The caller remains responsible for destroying cPix.
+func newPix(cPix *C.struct_Pix) (*leptonica.Pix, error) {
+	if cPix == nil {
+		return nil, errors.New("no image")
+	}
+
+	var cData *C.l_uint8
+	var cSize C.size_t
+	if C.pixWriteMem(&cData, &cSize, cPix, C.IFF_PNG) != 0 {
+		return nil, errors.New("could not encode image")
+	}
+	defer C.free(unsafe.Pointer(cData))
+	data := C.GoBytes(unsafe.Pointer(cData), C.int(cSize))
+
+	return leptonica.NewPixReadMem(&data)
+}
+
+// pixSize returns the width and height of pix
+func pixSize(pix *leptonica.Pix) (width, height int) {
+	cPix := (*C.struct_Pix)(unsafe.Pointer(pix.CPIX()))
+	return int(C.pixGetWidth(cPix)), int(C.pixGetHeight(cPix))
+}
+
+// destroyPix destroys a C PIX created by tesseract
+func destroyPix(cPix *C.struct_Pix) {
+	C.pixDestroy(&cPix)
+}
+
+// Component is a part of the page as found by tesseract's layout analysis.
+type Component struct {
+	// Rect is the bounding box of the component in image coordinates
+	Rect image.Rectangle
+	// Pix is the image of the component
+	Pix *leptonica.Pix
+	// BlockID is the id of the block that contains the component, or -1 when not provided by tesseract
+	BlockID int
+}
+
+// Components is a list of components as returned by (*Tess).Regions() and friends.
+type Components []Component
+
+// Rectangles returns the bounding boxes of all components.
+func (cs Components) Rectangles() []image.Rectangle {
+	rects := make([]image.Rectangle, 0, len(cs))
+	for _, c := range cs {
+		rects = append(rects, c.Rect)
+	}
+	return rects
+}
+
+// Close closes the images of all components.
+func (cs Components) Close() {
+	for _, c := range cs {
+		if c.Pix != nil {
+			c.Pix.Close()
+		}
+	}
+}
+
+// newComponents creates Components from the boxes, images and block ids returned by tesseract.
+// boxa and pixa are destroyed. blockIDs is not freed, it can be nil.
+func newComponents(boxa *C.struct_Boxa, pixa *C.struct_Pixa, blockIDs *C.int) (Components, error) {
+	if boxa == nil {
+		if pixa != nil {
+			C.pixaDestroy(&pixa)
+		}
+		return nil, errors.New("no components, is an image set?")
+	}
+	defer C.boxaDestroy(&boxa)
+	if pixa != nil {
+		defer C.pixaDestroy(&pixa)
+	}
+
+	count := int(C.boxaGetCount(boxa))
+	var cBlockIDs *[1 << 28]C.int
+	if blockIDs != nil {
+		cBlockIDs = (*[1 << 28]C.int)(unsafe.Pointer(blockIDs))
+	}
+
+	components := make(Components, 0, count)
+	for i := 0; i < count; i++ {
+		var x, y, w, h C.l_int32
+		C.boxaGetBoxGeometry(boxa, C.l_int32(i), &x, &y, &w, &h)
+		c := Component{
+			Rect:    image.Rect(int(x), int(y), int(x+w), int(y+h)),
+			BlockID: -1,
+		}
+		if cBlockIDs != nil {
+			c.BlockID = int(cBlockIDs[i])
+		}
+		if pixa != nil && i < int(C.pixaGetCount(pixa)) {
+			cPix := C.pixaGetPix(pixa, C.l_int32(i), C.L_CLONE)
+			pix, err := newPix(cPix)
+			destroyPix(cPix)
+			if err != nil {
+				components.Close()
+				return nil, err
+			}
+			c.Pix = pix
+		}
+		components = append(components, c)
+	}
+	return components, nil
+}
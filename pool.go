@@ -0,0 +1,139 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed is returned by Pool.Get after the pool was closed.
+var ErrPoolClosed = errors.New("tesseract pool is closed")
+
+// Pool is a pool of Tess instances for concurrent use.
+// An instance is taken from the pool for the duration of a document, so the adaptive classifier is kept across pages of
+// that document. When the instance is returned, the adaptive classifier is cleared so shapes learned from one document
+// don't influence the next.
+type Pool struct {
+	// KeepAdaptation disables clearing the adaptive classifier when an instance is returned to the pool.
+	KeepAdaptation bool
+
+	newTess func() (*Tess, error)
+	idle    chan *Tess
+	slots   chan struct{}
+	// reset and close are called for returned and closed instances, tests replace them
+	reset func(t *Tess)
+	close func(t *Tess)
+
+	// closedLock is held while instances are added to or drained from idle, so no instance is added after Close
+	closedLock sync.Mutex
+	closed     bool
+}
+
+// NewPool creates a pool of at most size instances. Instances are created with newTess when required.
+func NewPool(size int, newTess func() (*Tess, error)) *Pool {
+	p := &Pool{
+		newTess: newTess,
+		idle:    make(chan *Tess, size),
+		slots:   make(chan struct{}, size),
+		close:   (*Tess).Close,
+	}
+	p.reset = p.resetTess
+	return p
+}
+
+// Get takes an instance from the pool, or creates a new one when the pool isn't full.
+// When all instances are in use, Get waits for an instance to be returned or for ctx to be done.
+func (p *Pool) Get(ctx context.Context) (*Tess, error) {
+	if p.isClosed() {
+		return nil, ErrPoolClosed
+	}
+
+	// prefer an idle instance over creating a new one
+	select {
+	case t := <-p.idle:
+		return t, nil
+	default:
+	}
+
+	select {
+	case t := <-p.idle:
+		return t, nil
+	case p.slots <- struct{}{}:
+		t, err := p.newTess()
+		if err != nil {
+			<-p.slots
+			return nil, err
+		}
+		return t, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Put returns an instance to the pool. The recognition results and image are cleared,
+// and unless KeepAdaptation is set the adaptive classifier is cleared.
+func (p *Pool) Put(t *Tess) {
+	if p.isClosed() {
+		p.close(t)
+		<-p.slots
+		return
+	}
+
+	p.reset(t)
+
+	p.closedLock.Lock()
+	defer p.closedLock.Unlock()
+	if p.closed {
+		// closed while t was reset
+		p.close(t)
+		<-p.slots
+		return
+	}
+	// idle has room for every instance that holds a slot, so this doesn't block
+	p.idle <- t
+}
+
+func (p *Pool) resetTess(t *Tess) {
+	if !p.KeepAdaptation {
+		t.ClearAdaptiveClassifier()
+	}
+	t.Clear()
+	t.SetDiagnosticsTag("")
+}
+
+// Document takes an instance from the pool, calls fn with it and returns the instance to the pool.
+// Use this to process all pages of a single document with the same instance.
+func (p *Pool) Document(ctx context.Context, fn func(t *Tess) error) error {
+	t, err := p.Get(ctx)
+	if err != nil {
+		return err
+	}
+	defer p.Put(t)
+	return fn(t)
+}
+
+// Close closes all idle instances. Instances that are in use are closed when they are returned.
+func (p *Pool) Close() {
+	p.closedLock.Lock()
+	defer p.closedLock.Unlock()
+	p.closed = true
+
+	for {
+		select {
+		case t := <-p.idle:
+			p.close(t)
+			<-p.slots
+		default:
+			return
+		}
+	}
+}
+
+func (p *Pool) isClosed() bool {
+	p.closedLock.Lock()
+	defer p.closedLock.Unlock()
+	return p.closed
+}
@@ -0,0 +1,63 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// TestPoolPutClose returns instances while the pool is closed, every instance must be closed exactly once.
+func TestPoolPutClose(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var lock sync.Mutex
+		created := 0
+		closed := make(map[*Tess]int)
+		pool := NewPool(4, func() (*Tess, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			created++
+			return &Tess{}, nil
+		})
+		pool.reset = func(*Tess) {}
+		pool.close = func(tess *Tess) {
+			lock.Lock()
+			defer lock.Unlock()
+			closed[tess]++
+		}
+
+		var instances []*Tess
+		for j := 0; j < 4; j++ {
+			tess, err := pool.Get(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			instances = append(instances, tess)
+		}
+
+		var wg sync.WaitGroup
+		for _, tess := range instances {
+			wg.Add(1)
+			go func(tess *Tess) {
+				defer wg.Done()
+				pool.Put(tess)
+			}(tess)
+		}
+		pool.Close()
+		wg.Wait()
+
+		if created != 4 || len(closed) != 4 {
+			t.Fatalf("created %d instances, closed %d", created, len(closed))
+		}
+		for tess, n := range closed {
+			if n != 1 {
+				t.Fatalf("instance %p closed %d times", tess, n)
+			}
+		}
+		if _, err := pool.Get(context.Background()); err != ErrPoolClosed {
+			t.Fatalf("expected ErrPoolClosed, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package tesseract
+
+// #include "leptonica/allheaders.h"
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+)
+
+// Preprocessor improves an image before it is recognized, e.g. by binarising or deskewing it.
+type Preprocessor interface {
+	// Process returns a new image, pix is not modified. The caller must close the returned image.
+	Process(pix *leptonica.Pix) (*leptonica.Pix, error)
+}
+
+// PreprocessorFunc is a function that implements Preprocessor.
+type PreprocessorFunc func(pix *leptonica.Pix) (*leptonica.Pix, error)
+
+// Process calls f(pix)
+func (f PreprocessorFunc) Process(pix *leptonica.Pix) (*leptonica.Pix, error) {
+	return f(pix)
+}
+
+// Chain is a Preprocessor that runs its preprocessors in order, e.g.
+//
+//	Chain{Grayscale(), NormalizeContrast(), OtsuThreshold(), Deskew(), Despeckle(3)}
+type Chain []Preprocessor
+
+// Process runs all preprocessors of the chain, the intermediate images are closed.
+func (c Chain) Process(pix *leptonica.Pix) (*leptonica.Pix, error) {
+	current := pix
+	for i := 0; i < len(c); {
+		var next *leptonica.Pix
+		var err error
+
+		// consecutive leptonica stages pass the C PIX on directly, so the image is only copied once
+		stages := make([]*cStage, 0)
+		for ; i < len(c); i++ {
+			stage, ok := c[i].(*cStage)
+			if !ok {
+				break
+			}
+			stages = append(stages, stage)
+		}
+		if len(stages) > 0 {
+			next, err = runCStages((*C.struct_Pix)(unsafe.Pointer(current.CPIX())), stages)
+		} else {
+			next, err = c[i].Process(current)
+			i++
+		}
+
+		if current != pix {
+			current.Close()
+		}
+		if err != nil {
+			return nil, err
+		}
+		current = next
+	}
+	if current == pix {
+		// empty chain, the caller still gets an image of its own
+		return runCStages((*C.struct_Pix)(unsafe.Pointer(pix.CPIX())), nil)
+	}
+	return current, nil
+}
+
+// cStage is a preprocessor implemented with the leptonica C API.
+type cStage struct {
+	name string
+	// fn returns a new PIX or a clone of pixs, it must not destroy pixs
+	fn func(pixs *C.struct_Pix) (*C.struct_Pix, error)
+}
+
+// Process implements Preprocessor
+func (s *cStage) Process(pix *leptonica.Pix) (*leptonica.Pix, error) {
+	return runCStages((*C.struct_Pix)(unsafe.Pointer(pix.CPIX())), []*cStage{s})
+}
+
+// runCStages runs the stages on pixs and copies the result into a new leptonica.Pix. pixs is not destroyed.
+func runCStages(pixs *C.struct_Pix, stages []*cStage) (*leptonica.Pix, error) {
+	current := pixs
+	for _, s := range stages {
+		next, err := s.fn(current)
+		if err == nil && next == nil {
+			err = errors.New("failed")
+		}
+		if next == current {
+			// leptonica returned a clone, drop the extra reference
+			destroyPix(next)
+			continue
+		}
+		if current != pixs {
+			destroyPix(current)
+		}
+		if err != nil {
+			return nil, errors.New(s.name + ": " + err.Error())
+		}
+		current = next
+	}
+	if current != pixs {
+		defer destroyPix(current)
+	}
+	return newPix(current)
+}
+
+// Grayscale converts the image to 8 bit grayscale.
+func Grayscale() Preprocessor {
+	return &cStage{
+		name: "grayscale",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			return C.pixConvertTo8(pixs, 0), nil
+		},
+	}
+}
+
+// OtsuThreshold binarises the image with a global threshold found with Otsu's method.
+// It works well for evenly lit scans.
+func OtsuThreshold() Preprocessor {
+	return &cStage{
+		name: "otsu threshold",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			gray := C.pixConvertTo8(pixs, 0)
+			if gray == nil {
+				return nil, errors.New("could not convert to grayscale")
+			}
+			defer destroyPix(gray)
+
+			// a single tile that covers the whole image gives a global threshold
+			var pixd *C.struct_Pix
+			if C.pixOtsuAdaptiveThreshold(gray, C.pixGetWidth(gray), C.pixGetHeight(gray), 0, 0, 0.1, nil, &pixd) != 0 {
+				return nil, errors.New("failed")
+			}
+			return pixd, nil
+		},
+	}
+}
+
+// SauvolaThreshold binarises the image with a local threshold, computed over a window of windowSize pixels around each pixel.
+// It works better than OtsuThreshold for unevenly lit images like phone photos. A factor of 0.35 is a good start,
+// a higher factor makes the result lighter.
+func SauvolaThreshold(windowSize int, factor float32) Preprocessor {
+	return &cStage{
+		name: "sauvola threshold",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			if windowSize < 5 || factor < 0 {
+				return nil, errors.New("window size must be at least 5 and factor must not be negative")
+			}
+			gray := C.pixConvertTo8(pixs, 0)
+			if gray == nil {
+				return nil, errors.New("could not convert to grayscale")
+			}
+			defer destroyPix(gray)
+
+			var pixd *C.struct_Pix
+			if C.pixSauvolaBinarize(gray, C.l_int32(windowSize/2), C.l_float32(factor), 1, nil, nil, nil, &pixd) != 0 {
+				return nil, errors.New("failed")
+			}
+			return pixd, nil
+		},
+	}
+}
+
+// Deskew rotates the image so the text lines are level. Unlike (*Tess).Deskew, the skew is found by leptonica
+// before the image is given to tesseract.
+func Deskew() Preprocessor {
+	return &cStage{
+		name: "deskew",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			return C.pixDeskew(pixs, 0), nil
+		},
+	}
+}
+
+// Despeckle removes the connected components that are smaller than minSize pixels in both width and height.
+// The image must be binary, use it after OtsuThreshold or SauvolaThreshold.
+func Despeckle(minSize int) Preprocessor {
+	return &cStage{
+		name: "despeckle",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			if C.pixGetDepth(pixs) != 1 {
+				return nil, errors.New("image must be binary")
+			}
+			return C.pixSelectBySize(pixs, C.l_int32(minSize), C.l_int32(minSize), 8, C.L_SELECT_IF_EITHER, C.L_SELECT_IF_GTE, nil), nil
+		},
+	}
+}
+
+// RemoveBorder removes the connected components that touch the border of the image, like the dark edges of a
+// scanned or photographed page. The image must be binary, use it after OtsuThreshold or SauvolaThreshold.
+func RemoveBorder() Preprocessor {
+	return &cStage{
+		name: "remove border",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			if C.pixGetDepth(pixs) != 1 {
+				return nil, errors.New("image must be binary")
+			}
+			return C.pixRemoveBorderConnComps(pixs, 8), nil
+		},
+	}
+}
+
+// UpscaleToDPI scales the image up to dpi, tesseract works best at 300 dpi.
+// Images without resolution are assumed to be 70 dpi, like tesseract does. Images at or above dpi are not scaled.
+func UpscaleToDPI(dpi int) Preprocessor {
+	return &cStage{
+		name: "upscale",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			res := int(C.pixGetXRes(pixs))
+			if res <= 0 {
+				res = 70
+			}
+			if res >= dpi {
+				return C.pixClone(pixs), nil
+			}
+			factor := C.l_float32(float32(dpi) / float32(res))
+			pixd := C.pixScale(pixs, factor, factor)
+			if pixd != nil {
+				C.pixSetResolution(pixd, C.l_int32(dpi), C.l_int32(dpi))
+			}
+			return pixd, nil
+		},
+	}
+}
+
+// NormalizeContrast converts the image to grayscale and stretches the contrast in small tiles, which evens out
+// shadows and uneven lighting before binarisation.
+func NormalizeContrast() Preprocessor {
+	return &cStage{
+		name: "normalize contrast",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			gray := C.pixConvertTo8(pixs, 0)
+			if gray == nil {
+				return nil, errors.New("could not convert to grayscale")
+			}
+			defer destroyPix(gray)
+			return C.pixContrastNorm(nil, gray, 10, 10, 40, 2, 2), nil
+		},
+	}
+}
+
+// Invert inverts the image, for light text on a dark background.
+func Invert() Preprocessor {
+	return &cStage{
+		name: "invert",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			return C.pixInvert(nil, pixs), nil
+		},
+	}
+}
+
+// Scale scales the image by factor, e.g. 2 to double the width and height.
+func Scale(factor float32) Preprocessor {
+	return &cStage{
+		name: "scale",
+		fn: func(pixs *C.struct_Pix) (*C.struct_Pix, error) {
+			if factor <= 0 {
+				return nil, errors.New("factor must be positive")
+			}
+			return C.pixScale(pixs, C.l_float32(factor), C.l_float32(factor)), nil
+		},
+	}
+}
+
+// SetImagePixWith preprocesses pix with p and sets the result as the input image. pix is not modified.
+// The preprocessed image is kept by t until another image is set or t is closed.
+func (t *Tess) SetImagePixWith(pix *leptonica.Pix, p Preprocessor) error {
+	processed, err := p.Process(pix)
+	if err != nil {
+		return err
+	}
+	t.setOwnedImagePix(processed)
+	return nil
+}
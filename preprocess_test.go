@@ -0,0 +1,107 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+)
+
+// recordingStage returns a Preprocessor that records its name and checks that it gets the image of the previous stage.
+func recordingStage(t *testing.T, name string, calls *[]string, images map[string]*leptonica.Pix, previous string, err error) Preprocessor {
+	return PreprocessorFunc(func(pix *leptonica.Pix) (*leptonica.Pix, error) {
+		*calls = append(*calls, name)
+		if pix != images[previous] {
+			t.Errorf("%s: expected the image of %s", name, previous)
+		}
+		if err != nil {
+			return nil, err
+		}
+		images[name] = &leptonica.Pix{}
+		return images[name], nil
+	})
+}
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	images := map[string]*leptonica.Pix{"input": {}}
+	chain := Chain{
+		recordingStage(t, "a", &calls, images, "input", nil),
+		recordingStage(t, "b", &calls, images, "a", nil),
+		recordingStage(t, "c", &calls, images, "b", nil),
+	}
+	result, err := chain.Process(images["input"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(calls, ",") != "a,b,c" {
+		t.Errorf("expected the stages in order, got %v", calls)
+	}
+	if result != images["c"] {
+		t.Error("expected the image of the last stage")
+	}
+}
+
+func TestChainError(t *testing.T) {
+	var calls []string
+	images := map[string]*leptonica.Pix{"input": {}}
+	failed := errors.New("failed")
+	chain := Chain{
+		recordingStage(t, "a", &calls, images, "input", nil),
+		recordingStage(t, "b", &calls, images, "a", failed),
+		recordingStage(t, "c", &calls, images, "b", nil),
+	}
+	result, err := chain.Process(images["input"])
+	if err != failed || result != nil {
+		t.Errorf("expected the error of the failing stage, got %v, %v", result, err)
+	}
+	if strings.Join(calls, ",") != "a,b" {
+		t.Errorf("expected the chain to stop at the failing stage, got %v", calls)
+	}
+}
+
+func TestChainLeptonica(t *testing.T) {
+	pix, err := leptonica.NewPixFromFile(filepath.Join("tessexample", "getobMetWob.png"))
+	if err != nil {
+		t.Skip("leptonica can't read the test image: ", err)
+	}
+	defer pix.Close()
+	width, height := pixSize(pix)
+
+	// an empty chain returns a copy
+	identity, err := Chain{}.Process(pix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer identity.Close()
+	if identity == pix {
+		t.Error("expected a new image for an empty chain")
+	}
+	if w, h := pixSize(identity); w != width || h != height {
+		t.Errorf("expected %dx%d, got %dx%d", width, height, w, h)
+	}
+
+	// leptonica stages and Go stages mix
+	var calls []string
+	scaled, err := Chain{Grayscale(), Scale(2), PreprocessorFunc(func(pix *leptonica.Pix) (*leptonica.Pix, error) {
+		calls = append(calls, "go")
+		return Invert().Process(pix)
+	}), Scale(0.5)}.Process(pix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scaled.Close()
+	if w, h := pixSize(scaled); w != width || h != height || len(calls) != 1 {
+		t.Errorf("expected %dx%d after scaling up and down, got %dx%d", width, height, w, h)
+	}
+
+	_, err = Chain{Grayscale(), Scale(0), Invert()}.Process(pix)
+	if err == nil || !strings.HasPrefix(err.Error(), "scale: ") {
+		t.Errorf("expected the error of the scale stage, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package tesseract
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Rectangle is an area of the image, as used by SetRectangle.
+type Rectangle struct {
+	Left   int `json:"left" yaml:"left"`
+	Top    int `json:"top" yaml:"top"`
+	Width  int `json:"width" yaml:"width"`
+	Height int `json:"height" yaml:"height"`
+}
+
+// Profile is a named set of settings that is applied to a Tess in one call, for instance for invoices or receipts.
+// Fields that are not set are left as they are on the Tess.
+type Profile struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	PageSegMode *PageSegMode `json:"pageSegMode,omitempty" yaml:"pageSegMode,omitempty"`
+	// EngineMode can only be set when the Tess is created, see (*Profile).NewTess.
+	EngineMode *EngineMode       `json:"engineMode,omitempty" yaml:"engineMode,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Whitelist  string            `json:"whitelist,omitempty" yaml:"whitelist,omitempty"`
+	Blacklist  string            `json:"blacklist,omitempty" yaml:"blacklist,omitempty"`
+
+	// DPI and Rectangle apply to the current image, so the profile must be applied after SetImagePix or SetImage.
+	DPI       int        `json:"dpi,omitempty" yaml:"dpi,omitempty"`
+	Rectangle *Rectangle `json:"rectangle,omitempty" yaml:"rectangle,omitempty"`
+}
+
+// LoadProfile reads a single profile from a .json, .yaml or .yml file.
+func LoadProfile(filename string) (*Profile, error) {
+	p := &Profile{}
+	err := loadFile(filename, p)
+	if err != nil {
+		return nil, err
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	return p, nil
+}
+
+// LoadProfiles reads a set of profiles from a .json, .yaml or .yml file that maps profile names to profiles.
+func LoadProfiles(filename string) (map[string]*Profile, error) {
+	profiles := make(map[string]*Profile)
+	err := loadFile(filename, &profiles)
+	if err != nil {
+		return nil, err
+	}
+	for name, p := range profiles {
+		if p.Name == "" {
+			p.Name = name
+		}
+	}
+	return profiles, nil
+}
+
+// loadFile decodes a json or yaml file into v, depending on the file extension.
+func loadFile(filename string, v interface{}) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		err = json.Unmarshal(data, v)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, v)
+	default:
+		return errors.New("unsupported file type, expected .json, .yaml or .yml: " + filename)
+	}
+	if err != nil {
+		return errors.New(filename + ": " + err.Error())
+	}
+	return nil
+}
+
+// variables returns all variables set by the profile, including the white- and blacklist.
+func (p *Profile) variables() map[string]string {
+	vars := make(map[string]string, len(p.Variables)+2)
+	for name, value := range p.Variables {
+		vars[name] = value
+	}
+	if p.Whitelist != "" {
+		vars["tessedit_char_whitelist"] = NewCharSet(p.Whitelist).String()
+	}
+	if p.Blacklist != "" {
+		vars["tessedit_char_blacklist"] = NewCharSet(p.Blacklist).String()
+	}
+	return vars
+}
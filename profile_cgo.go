@@ -0,0 +1,119 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"errors"
+	"strconv"
+)
+
+// NewTess creates a new tesseract instance with the engine mode of the profile.
+// The rest of the profile is not applied, use Apply for that.
+func (p *Profile) NewTess(datapath string, language string) (*Tess, error) {
+	oem := OEM_DEFAULT
+	if p.EngineMode != nil {
+		oem = *p.EngineMode
+	}
+	return NewTessWithEngineMode(datapath, language, oem)
+}
+
+// AppliedProfile holds the settings a Tess had before a profile was applied.
+type AppliedProfile struct {
+	t           *Tess
+	pageSegMode *PageSegMode
+	variables   map[string]string
+	resetImage  bool
+}
+
+// Apply applies the profile to t. Use Revert on the result to restore the previous settings, so a pooled Tess doesn't
+// leak settings between jobs. When the profile can't be applied completely, the settings that were applied are reverted.
+func (p *Profile) Apply(t *Tess) (*AppliedProfile, error) {
+	if p.EngineMode != nil && *p.EngineMode != t.engineMode {
+		return nil, errors.New("profile " + p.Name + " requires engine mode " + p.EngineMode.String() + ", it can only be set when creating the Tess")
+	}
+	if (p.DPI != 0 || p.Rectangle != nil) && t.pix == nil && t.img == nil {
+		return nil, errors.New("profile " + p.Name + " has a DPI or rectangle, set an image before applying it")
+	}
+
+	applied := &AppliedProfile{
+		t:         t,
+		variables: make(map[string]string),
+	}
+	for name, value := range p.variables() {
+		previous, ok := t.variableString(name)
+		if !ok {
+			applied.Revert()
+			return nil, errors.New("profile " + p.Name + ": unknown variable " + name)
+		}
+		err := t.SetVariable(name, value)
+		if err != nil {
+			applied.Revert()
+			return nil, errors.New("profile " + p.Name + ": " + err.Error())
+		}
+		applied.variables[name] = previous
+	}
+
+	if p.PageSegMode != nil {
+		psm := t.PageSegMode()
+		applied.pageSegMode = &psm
+		t.SetPageSegMode(*p.PageSegMode)
+	}
+
+	if p.Rectangle != nil {
+		applied.resetImage = true
+		t.SetRectangle(p.Rectangle.Left, p.Rectangle.Top, p.Rectangle.Width, p.Rectangle.Height)
+	}
+	if p.DPI != 0 {
+		applied.resetImage = true
+		t.SetSourceResolution(p.DPI)
+	}
+
+	return applied, nil
+}
+
+// Revert restores the settings the Tess had before the profile was applied.
+// When the profile had a DPI or rectangle the image is set again, which clears the recognition results.
+func (a *AppliedProfile) Revert() error {
+	var firstErr error
+	for name, value := range a.variables {
+		err := a.t.SetVariable(name, value)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	a.variables = nil
+
+	if a.pageSegMode != nil {
+		a.t.SetPageSegMode(*a.pageSegMode)
+		a.pageSegMode = nil
+	}
+
+	// rectangle and resolution are reset by setting the image
+	if a.resetImage {
+		err := a.t.resetImage()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		a.resetImage = false
+	}
+
+	return firstErr
+}
+
+// variableString returns the value of a variable of any type, formatted so it can be passed to SetVariable.
+func (t *Tess) variableString(name string) (string, bool) {
+	if value, ok := t.IntVariable(name); ok {
+		return strconv.Itoa(value), true
+	}
+	if value, ok := t.BoolVariable(name); ok {
+		if value {
+			return "1", true
+		}
+		return "0", true
+	}
+	if value, ok := t.DoubleVariable(name); ok {
+		return strconv.FormatFloat(value, 'g', -1, 64), true
+	}
+	return t.StringVariable(name)
+}
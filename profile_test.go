@@ -0,0 +1,86 @@
+package tesseract
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestFile writes content to name in a temporary directory and returns its path.
+func writeTestFile(t *testing.T, name string, content string) string {
+	dir, err := ioutil.TempDir("", "gotess")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	filename := filepath.Join(dir, name)
+	err = ioutil.WriteFile(filename, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestLoadProfile(t *testing.T) {
+	yaml := writeTestFile(t, "invoices.yaml", "pageSegMode: 6\nwhitelist: \"0123456789.,\"\ndpi: 300\n"+
+		"rectangle: {left: 10, top: 20, width: 300, height: 40}\nvariables:\n  load_system_dawg: \"0\"\n")
+	p, err := LoadProfile(yaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "invoices" || p.PageSegMode == nil || *p.PageSegMode != PSM_SINGLE_BLOCK || p.DPI != 300 {
+		t.Errorf("unexpected profile: %+v", p)
+	}
+	if p.Rectangle == nil || *p.Rectangle != (Rectangle{Left: 10, Top: 20, Width: 300, Height: 40}) {
+		t.Errorf("unexpected rectangle: %v", p.Rectangle)
+	}
+	vars := p.variables()
+	if vars["load_system_dawg"] != "0" || vars["tessedit_char_whitelist"] != ",.0123456789" {
+		t.Errorf("unexpected variables: %v", vars)
+	}
+
+	json := writeTestFile(t, "profiles.json", `{"receipts": {"pageSegMode": "single_column"}, "named": {"name": "other"}}`)
+	profiles, err := LoadProfiles(json)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 2 || profiles["receipts"].Name != "receipts" || profiles["named"].Name != "other" {
+		t.Errorf("unexpected profiles: %v", profiles)
+	}
+
+	if _, err := LoadProfile(writeTestFile(t, "profile.toml", "")); err == nil {
+		t.Error("expected an error for an unsupported file type")
+	}
+	if _, err := LoadProfile(writeTestFile(t, "broken.json", "{")); err == nil {
+		t.Error("expected an error for invalid json")
+	}
+}
+
+func TestLoadZoneTemplate(t *testing.T) {
+	filename := writeTestFile(t, "slip.yaml", "dpi: 300\nzones:\n"+
+		"- name: amount\n  rectangle: {left: 0, top: 0, width: 100, height: 20}\n  whitelist: \"0123456789\"\n"+
+		"- name: name\n  rectangle: {left: 0, top: 30, width: 200, height: 20}\n")
+	zt, err := LoadZoneTemplate(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zt.Name != "slip" || zt.DPI != 300 || len(zt.Zones) != 2 || zt.Zones[0].Whitelist != "0123456789" {
+		t.Errorf("unexpected template: %+v", zt)
+	}
+	p := zt.Zones[1].profile(zt.DPI)
+	if p.Name != "name" || p.DPI != 300 || *p.Rectangle != zt.Zones[1].Rectangle {
+		t.Errorf("unexpected zone profile: %+v", p)
+	}
+
+	invalid := []*ZoneTemplate{
+		{Zones: []Zone{{Rectangle: Rectangle{Width: 1, Height: 1}}}},
+		{Zones: []Zone{{Name: "a", Rectangle: Rectangle{Width: 1, Height: 1}}, {Name: "a", Rectangle: Rectangle{Width: 1, Height: 1}}}},
+		{Zones: []Zone{{Name: "a", Rectangle: Rectangle{Width: 1}}}},
+	}
+	for i, zt := range invalid {
+		if zt.Validate() == nil {
+			t.Errorf("template %d: expected an error", i)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package tesseract
+
+// #include <stdlib.h>
+// #if defined(__GLIBC__)
+// #include <malloc.h>
+// #endif
+//
+// // c_heap_in_use returns the bytes allocated with malloc that are in use, or -1 when that is unknown.
+// static long long c_heap_in_use() {
+// #if defined(__GLIBC__) && (__GLIBC__ > 2 || (__GLIBC__ == 2 && __GLIBC_MINOR__ >= 33))
+// 	struct mallinfo2 mi = mallinfo2();
+// 	return (long long)mi.uordblks + (long long)mi.hblkhd;
+// #elif defined(__GLIBC__)
+// 	struct mallinfo mi = mallinfo();
+// 	return (long long)(unsigned int)mi.uordblks + (long long)(unsigned int)mi.hblkhd;
+// #else
+// 	return -1;
+// #endif
+// }
+import "C"
+
+import (
+	"time"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+)
+
+// Stats holds the time spent in each stage of RecognizeWithStats.
+type Stats struct {
+	SetImage time.Duration
+	// AnalyseLayout includes thresholding the image and finding the text lines
+	AnalyseLayout time.Duration
+	Recognize     time.Duration
+	Text          time.Duration
+	Total         time.Duration
+
+	// CHeapGrowth is the growth of the C heap in bytes from before SetImage until after Text, it includes memory that
+	// tesseract keeps for the recognition results. It's negative when memory was freed.
+	// CHeapGrowth is only available with glibc, see CHeapAvailable.
+	CHeapGrowth    int64
+	CHeapAvailable bool
+}
+
+// RecognizeWithStats sets pix as the input image, recognizes it and returns the text, like SetImagePix, Recognize
+// and Text. The time spent in each stage is returned in Stats.
+func (t *Tess) RecognizeWithStats(pix *leptonica.Pix) (string, Stats, error) {
+	var stats Stats
+	heapBefore := int64(C.c_heap_in_use())
+	start := time.Now()
+
+	t.SetImagePix(pix)
+	stats.SetImage = time.Since(start)
+
+	// layout analysis is kept and reused by Recognize. An empty page has no layout, Recognize handles that.
+	stageStart := time.Now()
+	if it, err := t.AnalyseLayout(); err == nil {
+		it.Close()
+	}
+	stats.AnalyseLayout = time.Since(stageStart)
+
+	stageStart = time.Now()
+	err := t.Recognize()
+	stats.Recognize = time.Since(stageStart)
+	if err != nil {
+		stats.Total = time.Since(start)
+		return "", stats, err
+	}
+
+	stageStart = time.Now()
+	text := t.Text()
+	stats.Text = time.Since(stageStart)
+	stats.Total = time.Since(start)
+
+	if heapBefore >= 0 {
+		stats.CHeapGrowth = int64(C.c_heap_in_use()) - heapBefore
+		stats.CHeapAvailable = true
+	}
+	return text, stats, nil
+}
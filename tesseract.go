@@ -1,18 +1,17 @@
 package tesseract
 
 // #cgo LDFLAGS: -L /usr/local/lib -ltesseract
+// #include "leptonica/allheaders.h"
 // #include "tesseract/capi.h"
 // #include <stdlib.h>
 import "C"
 
 import (
-	"bytes"
 	"errors"
-	"io"
+	"html"
+	"image"
+	"math"
 	"runtime"
-	"strconv"
-	"strings"
-	"unicode/utf8"
 	"unsafe"
 
 	"gopkg.in/GeertJohan/go.leptonica.v1"
@@ -25,21 +24,54 @@ const version = "1.0"
 // Tess represents a tesseract instance
 type Tess struct {
 	tba *C.TessBaseAPI
+
+	// datapath and engineMode are the values used at initialization
+	datapath   string
+	engineMode EngineMode
+
+	// pix is the image set with SetImagePix, ownedPix is set when that image was created by go.tesseract
+	pix      *leptonica.Pix
+	ownedPix *leptonica.Pix
+	// img is the image set with SetImage or RecognizeRect
+	img image.Image
+	// rect is the area set with SetRectangle, it's empty for the whole image
+	rect image.Rectangle
+
+	// diagnosticsTag and warnings are used when capturing diagnostics, see CaptureDiagnostics
+	diagnosticsTag string
+	warnings       []Warning
 }
 
 // const char* TessVersion();
 
-// Version returns both go.tesseract's version as well as the version from the tesseract lib (>3.02.02)
+// Version returns both go.tesseract's version as well as the version from the tesseract lib (>=3.04)
 func Version() string {
 	libTessVersion := C.TessVersion()
 	return "go.tesseract:" + version + " tesseract lib:" + C.GoString(libTessVersion)
 }
 
 // TessBaseAPI* TessBaseAPICreate();
-// int TessBaseAPIInit3(TessBaseAPI* handle, const char* datapath, const char* language);
+// int TessBaseAPIInit1(TessBaseAPI* handle, const char* datapath, const char* language, TessOcrEngineMode oem, char** configs, int configs_size);
 
 // NewTess creates and returns a new tesseract instance.
 func NewTess(datapath string, language string) (*Tess, error) {
+	return newTess(datapath, language, OEM_DEFAULT, nil)
+}
+
+// NewTessWithEngineMode creates and returns a new tesseract instance that uses given engine mode.
+func NewTessWithEngineMode(datapath string, language string, oem EngineMode) (*Tess, error) {
+	return newTess(datapath, language, oem, nil)
+}
+
+// NewTessWithConfigs creates and returns a new tesseract instance that reads the given config files during
+// initialization, like the tesseract command does. Config files can set init-only variables, and are searched in
+// the tessdata/configs directory when they are not found.
+func NewTessWithConfigs(datapath string, language string, oem EngineMode, configs []string) (*Tess, error) {
+	return newTess(datapath, language, oem, configs)
+}
+
+// newTess creates a new tesseract instance, the config files are read during initialization.
+func newTess(datapath string, language string, oem EngineMode, configs []string) (*Tess, error) {
 	// create new empty TessBaseAPI
 	tba := C.TessBaseAPICreate()
 
@@ -51,15 +83,30 @@ func NewTess(datapath string, language string) (*Tess, error) {
 	cLanguage := C.CString(language)
 	defer C.free(unsafe.Pointer(cLanguage))
 
+	// prepare config files for C call
+	var cConfigs **C.char
+	if len(configs) > 0 {
+		cConfigs = (**C.char)(C.malloc(C.size_t(len(configs)) * C.size_t(unsafe.Sizeof(cConfigs))))
+		defer C.free(unsafe.Pointer(cConfigs))
+		cConfigsArray := (*[1 << 28]*C.char)(unsafe.Pointer(cConfigs))
+		for i, config := range configs {
+			cConfigsArray[i] = C.CString(config)
+			defer C.free(unsafe.Pointer(cConfigsArray[i]))
+		}
+	}
+
 	// initialize datapath and language on TessBaseAPI
-	res := C.TessBaseAPIInit3(tba, cDatapath, cLanguage)
+	res := C.TessBaseAPIInit1(tba, cDatapath, cLanguage, C.TessOcrEngineMode(oem), cConfigs, C.int(len(configs)))
 	if res != 0 {
+		C.TessBaseAPIDelete(tba)
 		return nil, errors.New("could not initiate new Tess instance")
 	}
 
 	// create tesseract instance (Tess)
 	tess := &Tess{
-		tba: tba,
+		tba:        tba,
+		datapath:   datapath,
+		engineMode: oem,
 	}
 
 	// set GC finalizer, to be ran in case the user forgets to call Close()
@@ -82,6 +129,7 @@ func (t *Tess) delete() {
 func (t *Tess) Close() {
 	t.delete()
 	t.tba = nil
+	t.releaseOwnedPix()
 }
 
 /* void TessBaseAPIClear(TessBaseAPI* handle);
@@ -94,8 +142,14 @@ any Recognize or Get* operation.
 
 // Clear frees up recognition results and any stored image data, without actually freeing any recognition data that would be time-consuming to reload.
 // Afterwards, you must call SetImagePix before doing any Recognize or Get* operation.
+// After Clear, t no longer uses the Pix or image that was set, so it can be closed.
 func (t *Tess) Clear() {
 	C.TessBaseAPIClear(t.tba)
+	t.pix = nil
+	t.img = nil
+	t.rect = image.Rectangle{}
+	t.releaseOwnedPix()
+	t.warnings = nil
 }
 
 // map t.delete() on t GC as hook/callback in NewXXX() call's
@@ -116,9 +170,33 @@ func (t *Tess) SetInputName(filename string) {
 
 // void TessBaseAPISetImage2(TessBaseAPI* handle, const PIX* pix);
 
-// SetImagePix sets the input image using a leptonica Pix
+// SetImagePix sets the input image using a leptonica Pix.
+// pix is used by t until another image is set or Clear is called, so it must not be closed before that.
 func (t *Tess) SetImagePix(pix *leptonica.Pix) {
+	t.releaseOwnedPix()
+	t.setImagePix(pix)
+}
+
+func (t *Tess) setImagePix(pix *leptonica.Pix) {
 	C.TessBaseAPISetImage2(t.tba, (*C.struct_Pix)(unsafe.Pointer(pix.CPIX())))
+	t.pix = pix
+	t.img = nil
+	t.rect = image.Rectangle{}
+	t.warnings = nil
+}
+
+// setOwnedImagePix sets an image that was created by go.tesseract, it is closed when it's replaced or when t is closed.
+func (t *Tess) setOwnedImagePix(pix *leptonica.Pix) {
+	t.releaseOwnedPix()
+	t.setImagePix(pix)
+	t.ownedPix = pix
+}
+
+func (t *Tess) releaseOwnedPix() {
+	if t.ownedPix != nil {
+		t.ownedPix.Close()
+		t.ownedPix = nil
+	}
 }
 
 /* char* TessBaseAPIGetUTF8Text(TessBaseAPI* handle);
@@ -128,7 +206,10 @@ Make a text string from the internal data structures.
 
 // Text returns text after analysing the image(s)
 func (t *Tess) Text() string {
-	cText := C.TessBaseAPIGetUTF8Text(t.tba)
+	var cText *C.char
+	t.capture(func() {
+		cText = C.TessBaseAPIGetUTF8Text(t.tba)
+	})
 	defer C.free(unsafe.Pointer(cText))
 	text := C.GoString(cText)
 	return text
@@ -144,14 +225,39 @@ GetHOCRText
 STL removed from original patch submission and refactored by rays.
 */
 
-// HOCRText returns the HOCR text for given pagenumber
+// HOCRText returns the HOCR text for given pagenumber. This is an ocr_page div, put the pages between HOCRHeader and
+// HOCRFooter for an hOCR document.
 func (t *Tess) HOCRText(pagenumber int) string {
-	cText := C.TessBaseAPIGetHOCRText(t.tba, C.int(pagenumber))
+	var cText *C.char
+	t.capture(func() {
+		cText = C.TessBaseAPIGetHOCRText(t.tba, C.int(pagenumber))
+	})
 	defer C.free(unsafe.Pointer(cText))
 	text := C.GoString(cText)
 	return text
 }
 
+// HOCRHeader returns the start of an hOCR document with given title, up to the body, like the tesseract hocr renderer
+// writes it.
+func HOCRHeader(title string) string {
+	return "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" +
+		"<!DOCTYPE html PUBLIC \"-//W3C//DTD XHTML 1.0 Transitional//EN\"\n" +
+		"    \"http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd\">\n" +
+		"<html xmlns=\"http://www.w3.org/1999/xhtml\" xml:lang=\"en\" lang=\"en\">\n" +
+		" <head>\n" +
+		"  <title>" + html.EscapeString(title) + "</title>\n" +
+		"  <meta http-equiv=\"Content-Type\" content=\"text/html;charset=utf-8\"/>\n" +
+		"  <meta name='ocr-system' content='tesseract " + html.EscapeString(C.GoString(C.TessVersion())) + "' />\n" +
+		"  <meta name='ocr-capabilities' content='ocr_page ocr_carea ocr_par ocr_line ocrx_word ocrp_wconf'/>\n" +
+		" </head>\n" +
+		" <body>\n"
+}
+
+// HOCRFooter returns the end of an hOCR document, see HOCRHeader.
+func HOCRFooter() string {
+	return " </body>\n</html>\n"
+}
+
 /* char* TessBaseAPIGetBoxText(TessBaseAPI* handle, int page_number);
 
 The recognized text is returned as a char* which is coded
@@ -161,106 +267,32 @@ page_number is a 0-base page index that will appear in the box file.
 
 // BoxTextRaw returns the raw box text for given pagenumber
 func (t *Tess) BoxTextRaw(pagenumber int) string {
-	cText := C.TessBaseAPIGetBoxText(t.tba, C.int(pagenumber))
+	var cText *C.char
+	t.capture(func() {
+		cText = C.TessBaseAPIGetBoxText(t.tba, C.int(pagenumber))
+	})
 	defer C.free(unsafe.Pointer(cText))
 	text := C.GoString(cText)
 	return text
 }
 
-// TODO: make this: `type BoxText []BoxCharacter` ?
-type BoxText struct {
-	Characters []BoxCharacter
-}
-
-type BoxCharacter struct {
-	Character  rune
-	StartX     uint32
-	StartY     uint32
-	EndX       uint32
-	EndY       uint32
-	Pagenumber uint32
-}
-
 // BoxText returns the output given by BoxTextRaw as BoxText object
 func (tess *Tess) BoxText(pagenumber int) (*BoxText, error) {
-	text := tess.BoxTextRaw(pagenumber)
-	textBuffer := bytes.NewBufferString(text)
-
-	bt := &BoxText{
-		Characters: make([]BoxCharacter, 0, len(text)),
-	}
-	for {
-		line, err := textBuffer.ReadString('\n')
-		if err != nil {
-			if err == io.EOF {
-				return bt, nil
-			}
-			return nil, err
-		}
-		line = strings.TrimRight(line, "\n")
-		fields := strings.Split(line, " ")
-		if len(fields) != 6 {
-			f := strconv.Itoa(len(fields))
-			return nil, errors.New("unexpected BoxText format (Length != 6) Length is: " + f)
-		}
-		if utf8.RuneCountInString(fields[0]) != 1 {
-			return nil, errors.New("unexpected BoxText format (RuneCount error): " + fields[0])
-		}
-
-		sx, err := strconv.ParseUint(fields[1], 10, 32)
-		if err != nil {
-			return nil, err
-		}
-		sy, err := strconv.ParseUint(fields[2], 10, 32)
-		if err != nil {
-			return nil, err
-		}
-		ex, err := strconv.ParseUint(fields[3], 10, 32)
-		if err != nil {
-			return nil, err
-		}
-		ey, err := strconv.ParseUint(fields[4], 10, 32)
-		if err != nil {
-			return nil, err
-		}
-		pgnr, err := strconv.ParseUint(fields[5], 10, 32)
-		if err != nil {
-			return nil, err
-		}
-		bt.Characters = append(bt.Characters, BoxCharacter{
-			Character:  rune(fields[0][0]),
-			StartX:     uint32(sx),
-			StartY:     uint32(sy),
-			EndX:       uint32(ex),
-			EndY:       uint32(ey),
-			Pagenumber: uint32(pgnr),
-		})
-	}
+	return ParseBoxText(tess.BoxTextRaw(pagenumber))
 }
 
-// typedef enum TessPageSegMode { PSM_OSD_ONLY, PSM_AUTO_OSD, PSM_AUTO_ONLY, PSM_AUTO, PSM_SINGLE_COLUMN, PSM_SINGLE_BLOCK_VERT_TEXT, PSM_SINGLE_BLOCK, PSM_SINGLE_LINE, PSM_SINGLE_WORD, PSM_CIRCLE_WORD, PSM_SINGLE_CHAR, PSM_COUNT } TessPageSegMode;
-type PageSegMode int
-
-const (
-	PSM_OSD_ONLY PageSegMode = iota
-	PSM_AUTO_OSD
-	PSM_AUTO_ONLY
-	PSM_AUTO
-	PSM_SINGLE_COLUMN
-	PSM_SINGLE_BLOCK_VERT_TEXT
-	PSM_SINGLE_BLOCK
-	PSM_SINGLE_LINE
-	PSM_SINGLE_WORD
-	PSM_CIRCLE_WORD
-	PSM_SINGLE_CHAR
-	PSM_COUNT
-)
-
 // void TessBaseAPISetPageSegMode(TessBaseAPI* handle, TessPageSegMode mode);
 func (tess *Tess) SetPageSegMode(psm PageSegMode) {
 	C.TessBaseAPISetPageSegMode(tess.tba, C.TessPageSegMode(psm))
 }
 
+// TessPageSegMode TessBaseAPIGetPageSegMode(const TessBaseAPI* handle);
+
+// PageSegMode returns the page seg mode that is currently set
+func (t *Tess) PageSegMode() PageSegMode {
+	return PageSegMode(C.TessBaseAPIGetPageSegMode(t.tba))
+}
+
 /* char* TessBaseAPIGetUNLVText(TessBaseAPI* handle);
 
 The recognized text is returned as a char* which is coded
@@ -270,7 +302,10 @@ and must be freed with the delete [] operator.
 
 // UNLVText returns the UNLV text
 func (t *Tess) UNLVText() string {
-	cText := C.TessBaseAPIGetUNLVText(t.tba)
+	var cText *C.char
+	t.capture(func() {
+		cText = C.TessBaseAPIGetUNLVText(t.tba)
+	})
 	defer C.free(unsafe.Pointer(cText))
 	text := C.GoString(cText)
 	return text
@@ -353,30 +388,371 @@ func (t *Tess) SetVariable(name, value string) error {
 	return nil
 }
 
+// BOOL TessBaseAPIGetIntVariable( const TessBaseAPI* handle, const char* name, int* value);
+
+// IntVariable returns the value of an int variable, ok is false when there is no int variable with that name.
+func (t *Tess) IntVariable(name string) (value int, ok bool) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cValue C.int
+	ok = gobool(C.TessBaseAPIGetIntVariable(t.tba, cName, &cValue))
+	return int(cValue), ok
+}
+
+// BOOL TessBaseAPIGetBoolVariable( const TessBaseAPI* handle, const char* name, BOOL* value);
+
+// BoolVariable returns the value of a bool variable, ok is false when there is no bool variable with that name.
+func (t *Tess) BoolVariable(name string) (value bool, ok bool) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cValue C.int
+	ok = gobool(C.TessBaseAPIGetBoolVariable(t.tba, cName, &cValue))
+	return gobool(cValue), ok
+}
+
+// BOOL TessBaseAPIGetDoubleVariable(const TessBaseAPI* handle, const char* name, double* value);
+
+// DoubleVariable returns the value of a double variable, ok is false when there is no double variable with that name.
+func (t *Tess) DoubleVariable(name string) (value float64, ok bool) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cValue C.double
+	ok = gobool(C.TessBaseAPIGetDoubleVariable(t.tba, cName, &cValue))
+	return float64(cValue), ok
+}
+
+// const char* TessBaseAPIGetStringVariable(const TessBaseAPI* handle, const char* name);
+
+// StringVariable returns the value of a string variable, ok is false when there is no string variable with that name.
+func (t *Tess) StringVariable(name string) (value string, ok bool) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	// the returned string is owned by tesseract and must not be freed
+	cValue := C.TessBaseAPIGetStringVariable(t.tba, cName)
+	if cValue == nil {
+		return "", false
+	}
+	return C.GoString(cValue), true
+}
+
+// HasVariable returns true when tesseract knows a variable with given name, of any type.
+func (t *Tess) HasVariable(name string) bool {
+	if _, ok := t.IntVariable(name); ok {
+		return true
+	}
+	if _, ok := t.BoolVariable(name); ok {
+		return true
+	}
+	if _, ok := t.DoubleVariable(name); ok {
+		return true
+	}
+	_, ok := t.StringVariable(name)
+	return ok
+}
+
+/* void TessBaseAPIReadConfigFile(TessBaseAPI* handle, const char* filename);
+
+Read a "config" file containing a set of param, value pairs.
+Searches the standard places: tessdata/configs, tessdata/tessconfigs
+and also accepts a relative or absolute path name.
+Note: only non-init params will be set (init params are set by Init()).
+*/
+
+// ReadConfigFile reads a config file with `name value` lines and sets the variables.
+// The file is searched in tessdata/configs and tessdata/tessconfigs, a relative or absolute path is accepted as well.
+// Only variables that can be set after initialization are set.
+// Use LoadConfigFile to validate the file before it is applied.
+func (t *Tess) ReadConfigFile(filename string) {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+	C.TessBaseAPIReadConfigFile(t.tba, cFilename)
+}
+
+/* void TessBaseAPIReadDebugConfigFile(TessBaseAPI* handle, const char* filename);
+
+Same as above, but only set debug params from the given config file.
+*/
+
+// ReadDebugConfigFile is like ReadConfigFile, but only sets debug variables.
+func (t *Tess) ReadDebugConfigFile(filename string) {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+	C.TessBaseAPIReadDebugConfigFile(t.tba, cFilename)
+}
+
+/* void TessBaseAPISetSourceResolution(TessBaseAPI* handle, int ppi);
+
+Set the resolution of the source image in pixels per inch so font size
+information can be calculated in results.  Call this after SetImage().
+*/
+
+// SetSourceResolution sets the resolution of the image in pixels per inch. Call this after SetImagePix.
+func (t *Tess) SetSourceResolution(ppi int) {
+	C.TessBaseAPISetSourceResolution(t.tba, C.int(ppi))
+}
+
 // void TessBaseAPISetRectangle(TessBaseAPI* handle, int left, int top, int width, int height);
 func (t *Tess) SetRectangle(left, top, width, height int) {
 	C.TessBaseAPISetRectangle(t.tba, C.int(left), C.int(top), C.int(width), C.int(height))
+	t.rect = image.Rect(left, top, left+width, top+height)
 }
 
 // int TessBaseAPIRecognize(TessBaseAPI* handle, ETEXT_DESC* monitor);
 func (t *Tess) Recognize() error {
-	ret := C.TessBaseAPIRecognize(t.tba, nil)
+	var ret C.int
+	t.capture(func() {
+		ret = C.TessBaseAPIRecognize(t.tba, nil)
+	})
 	if ret != 0 {
 		return errors.New("recognition failed")
 	}
 	return nil
 }
 
-// typedef enum TessPageIteratorLevel { RIL_BLOCK, RIL_PARA, RIL_TEXTLINE, RIL_WORD, RIL_SYMBOL} TessPageIteratorLevel;
-type PageIteratorLevel int
-
-const (
-	RIL_BLOCK PageIteratorLevel = iota
-	RIL_PARA
-	RIL_TEXTLINE
-	RIL_WORD
-	RIL_SYMBOL
-)
+/* int TessBaseAPIMeanTextConf(TessBaseAPI* handle);
+
+Returns the (average) confidence value between 0 and 100.
+*/
+
+// MeanTextConfidence returns the average confidence of the recognized words, between 0 and 100.
+// When the image has not been recognized yet, this will run recognition first.
+func (t *Tess) MeanTextConfidence() int {
+	var conf C.int
+	t.capture(func() {
+		conf = C.TessBaseAPIMeanTextConf(t.tba)
+	})
+	return int(conf)
+}
+
+/* int* TessBaseAPIAllWordConfidences(TessBaseAPI* handle);
+
+Returns all word confidences (between 0 and 100) in an array, terminated
+by -1.  The calling function must delete [] after use.
+The number of confidences should correspond to the number of space-
+delimited words in GetUTF8Text.
+*/
+
+// WordConfidences returns the confidences (between 0 and 100) for all recognized words.
+// The confidences follow the space-delimited words of Text, which don't always match the words of Words or the
+// iterator at RIL_WORD level. Use Words for words with their confidence.
+// When the image has not been recognized yet, this will run recognition first.
+func (t *Tess) WordConfidences() []int {
+	var cConfs *C.int
+	t.capture(func() {
+		cConfs = C.TessBaseAPIAllWordConfidences(t.tba)
+	})
+	if cConfs == nil {
+		return nil
+	}
+	defer C.TessDeleteIntArray(cConfs)
+
+	cConfsArray := (*[1 << 28]C.int)(unsafe.Pointer(cConfs))
+	confs := make([]int, 0)
+	for _, conf := range cConfsArray {
+		if conf == -1 {
+			return confs
+		}
+		confs = append(confs, int(conf))
+	}
+	return confs
+}
+
+/* PIX* TessBaseAPIGetThresholdedImage( TessBaseAPI* handle);
+
+Get a copy of the internal thresholded image from Tesseract.
+Caller takes ownership of the Pix and must pixDestroy it.
+May be called any time after SetImage, or after TesseractRect.
+*/
+
+// ThresholdedImage returns a copy of the thresholded (binary) image that tesseract uses for recognition.
+// This can be called any time after SetImagePix. The caller must Close the returned Pix.
+func (t *Tess) ThresholdedImage() (*leptonica.Pix, error) {
+	cPix := C.TessBaseAPIGetThresholdedImage(t.tba)
+	if cPix == nil {
+		return nil, errors.New("no thresholded image, is an image set?")
+	}
+	defer destroyPix(cPix)
+	return newPix(cPix)
+}
+
+/* int TessBaseAPIGetThresholdedImageScaleFactor(const TessBaseAPI* handle);
+
+Returns the scale factor of the thresholded image that would be returned by
+GetThresholdedImage() and the various GetX() methods that call
+GetComponentImages().
+Returns 0 if no thresholder has been set.
+*/
+
+// ThresholdedImageScaleFactor returns the scale factor of the thresholded image and the components returned by Regions, Textlines, etc.
+// Returns 0 when no image is set.
+func (t *Tess) ThresholdedImageScaleFactor() int {
+	return int(C.TessBaseAPIGetThresholdedImageScaleFactor(t.tba))
+}
+
+/* BOXA* TessBaseAPIGetRegions( TessBaseAPI* handle, PIXA** pixa);
+
+Get the result of page layout analysis as a leptonica-style
+Boxa, Pixa pair, in reading order.
+Can be called before or after Recognize.
+*/
+
+// Regions returns the result of page layout analysis, in reading order.
+// Can be called before or after Recognize. The caller must Close the returned Components.
+func (t *Tess) Regions() (Components, error) {
+	var pixa *C.struct_Pixa
+	boxa := C.TessBaseAPIGetRegions(t.tba, &pixa)
+	return newComponents(boxa, pixa, nil)
+}
+
+/* BOXA* TessBaseAPIGetTextlines( TessBaseAPI* handle, PIXA** pixa, int** blockids);
+
+Get the textlines as a leptonica-style
+Boxa, Pixa pair, in reading order.
+Can be called before or after Recognize.
+If blockids is not NULL, the block-id of each line is also returned as an
+array of one element per line. delete [] after use.
+*/
+
+// Textlines returns the textlines in reading order, including the block id of each line.
+// Can be called before or after Recognize. The caller must Close the returned Components.
+func (t *Tess) Textlines() (Components, error) {
+	var pixa *C.struct_Pixa
+	var blockIDs *C.int
+	boxa := C.TessBaseAPIGetTextlines(t.tba, &pixa, &blockIDs)
+	if blockIDs != nil {
+		defer C.TessDeleteIntArray(blockIDs)
+	}
+	return newComponents(boxa, pixa, blockIDs)
+}
+
+/* BOXA* TessBaseAPIGetStrips( TessBaseAPI* handle, PIXA** pixa, int** blockids);
+
+Get textlines and strips of image regions as a leptonica-style Boxa, Pixa
+pair, in reading order. Enables downstream handling of non-rectangular
+regions.
+Can be called before or after Recognize.
+If blockids is not NULL, the block-id of each line is also returned as an
+array of one element per line. delete [] after use.
+*/
+
+// Strips returns the textlines and strips of image regions in reading order, including the block id of each strip.
+// Can be called before or after Recognize. The caller must Close the returned Components.
+func (t *Tess) Strips() (Components, error) {
+	var pixa *C.struct_Pixa
+	var blockIDs *C.int
+	boxa := C.TessBaseAPIGetStrips(t.tba, &pixa, &blockIDs)
+	if blockIDs != nil {
+		defer C.TessDeleteIntArray(blockIDs)
+	}
+	return newComponents(boxa, pixa, blockIDs)
+}
+
+/* BOXA* TessBaseAPIGetWords( TessBaseAPI* handle, PIXA** pixa);
+
+Get the words as a leptonica-style
+Boxa, Pixa pair, in reading order.
+Can be called before or after Recognize.
+*/
+
+// WordImages returns the words as found by layout analysis, in reading order.
+// Can be called before or after Recognize. The caller must Close the returned Components.
+func (t *Tess) WordImages() (Components, error) {
+	var pixa *C.struct_Pixa
+	boxa := C.TessBaseAPIGetWords(t.tba, &pixa)
+	return newComponents(boxa, pixa, nil)
+}
+
+/* BOXA* TessBaseAPIGetConnectedComponents(TessBaseAPI* handle, PIXA** cc);
+
+Gets the individual connected (text) components (created
+after pages segmentation step, but before recognition)
+as a leptonica-style Boxa, Pixa pair, in reading order.
+Can be called before or after Recognize.
+*/
+
+// ConnectedComponents returns the individual connected (text) components in reading order.
+// Can be called before or after Recognize. The caller must Close the returned Components.
+func (t *Tess) ConnectedComponents() (Components, error) {
+	var pixa *C.struct_Pixa
+	boxa := C.TessBaseAPIGetConnectedComponents(t.tba, &pixa)
+	return newComponents(boxa, pixa, nil)
+}
+
+/* BOXA* TessBaseAPIGetComponentImages( TessBaseAPI* handle, TessPageIteratorLevel level, BOOL text_only, PIXA** pixa, int** blockids);
+
+Get the given level kind of components (block, textline, word etc.) as a
+leptonica-style Boxa, Pixa pair, in reading order.
+Can be called before or after Recognize.
+If blockids is not NULL, the block-id of each component is also returned
+as an array of one element per component. delete [] after use.
+If text_only is true, then only text components are returned.
+*/
+
+// ComponentImages returns the components at given level (block, textline, word, etc.) in reading order, including their block ids.
+// When textOnly is true, only text components are returned.
+// Can be called before or after Recognize. The caller must Close the returned Components.
+func (t *Tess) ComponentImages(level PageIteratorLevel, textOnly bool) (Components, error) {
+	var pixa *C.struct_Pixa
+	var blockIDs *C.int
+	boxa := C.TessBaseAPIGetComponentImages(t.tba, C.TessPageIteratorLevel(level), cbool(textOnly), &pixa, &blockIDs)
+	if blockIDs != nil {
+		defer C.TessDeleteIntArray(blockIDs)
+	}
+	return newComponents(boxa, pixa, blockIDs)
+}
+
+/* void TessBaseAPIClearAdaptiveClassifier(TessBaseAPI* handle);
+
+Call between pages or documents etc to free up memory and forget
+adaptive data.
+*/
+
+// ClearAdaptiveClassifier makes tesseract forget the shapes it learned from earlier pages.
+// Call this between documents, and keep the adaptation between pages of the same document.
+func (t *Tess) ClearAdaptiveClassifier() {
+	C.TessBaseAPIClearAdaptiveClassifier(t.tba)
+}
+
+/* BOOL TessBaseAPIAdaptToWordStr(TessBaseAPI* handle, TessPageSegMode mode, const char* wordstr);
+
+Applies the given word to the adaptive classifier if possible.
+The word must be SPACE-DELIMITED UTF-8 - l i k e t h i s , so it can
+tell the boundaries of the graphemes.
+Assumes that SetImage/SetRectangle have been used to set the image
+to the given word. The mode arg should be PSM_SINGLE_WORD or
+PSM_CIRCLE_WORD, as that will be used to control layout analysis.
+The currently set PageSegMode is preserved.
+Returns false if adaption was not possible for some reason.
+*/
+
+// AdaptToWordStr trains the adaptive classifier with the given word for the image (or rectangle) that is currently set.
+// The word must have its characters separated by spaces, "l i k e t h i s". Mode should be PSM_SINGLE_WORD or PSM_CIRCLE_WORD.
+func (t *Tess) AdaptToWordStr(mode PageSegMode, word string) error {
+	cWord := C.CString(word)
+	defer C.free(unsafe.Pointer(cWord))
+
+	var res C.int
+	t.capture(func() {
+		res = C.TessBaseAPIAdaptToWordStr(t.tba, C.TessPageSegMode(mode), cWord)
+	})
+	if res == 0 {
+		return errors.New("could not adapt to word: " + word)
+	}
+	return nil
+}
+
+// int TessBaseAPIIsValidWord(TessBaseAPI* handle, const char *word);
+
+// IsValidWord returns true when word is found in one of the loaded dictionaries.
+func (t *Tess) IsValidWord(word string) bool {
+	cWord := C.CString(word)
+	defer C.free(unsafe.Pointer(cWord))
+	return C.TessBaseAPIIsValidWord(t.tba, cWord) != 0
+}
 
 // TessResultIterator* TessBaseAPIGetIterator(TessBaseAPI* handle);
 func (t *Tess) Iterator() (*ResultIterator, error) {
@@ -394,6 +770,17 @@ func (t *Tess) Iterator() (*ResultIterator, error) {
 	return resultIterator, nil
 }
 
+// Results returns the result of Iterator as an Iterator, for use through the Engine interface.
+func (t *Tess) Results() (Iterator, error) {
+	it, err := t.Iterator()
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+var _ Engine = (*Tess)(nil)
+
 // typedef struct TessResultIterator TessResultIterator;
 type ResultIterator struct {
 	ri *C.TessResultIterator
@@ -422,15 +809,252 @@ func (r *ResultIterator) Text(level PageIteratorLevel) (string, error) {
 	return text, nil
 }
 
-// typedef struct TessPageIterator TessPageIterator;
+// float TessResultIteratorConfidence(const TessResultIterator* handle, TessPageIteratorLevel level);
+
+// Confidence returns the confidence (between 0 and 100) of the current element at given level.
+func (r *ResultIterator) Confidence(level PageIteratorLevel) float32 {
+	return float32(C.TessResultIteratorConfidence(r.ri, C.TessPageIteratorLevel(level)))
+}
+
+// BOOL TessResultIteratorWordIsFromDictionary(const TessResultIterator* handle);
+
+// WordIsFromDictionary returns true when the current word was found in a dictionary.
+func (r *ResultIterator) WordIsFromDictionary() bool {
+	return gobool(C.TessResultIteratorWordIsFromDictionary(r.ri))
+}
+
+// BOOL TessResultIteratorWordIsNumeric(const TessResultIterator* handle);
+
+// WordIsNumeric returns true when the current word is numeric.
+func (r *ResultIterator) WordIsNumeric() bool {
+	return gobool(C.TessResultIteratorWordIsNumeric(r.ri))
+}
+
+// const TessPageIterator* TessResultIteratorGetPageIteratorConst(const TessResultIterator* handle);
+
+// BoundingBox returns the bounding box of the current element at given level, in image coordinates.
+// ok is false when the iterator is at the end.
+func (r *ResultIterator) BoundingBox(level PageIteratorLevel) (rect image.Rectangle, ok bool) {
+	return boundingBox(C.TessResultIteratorGetPageIteratorConst(r.ri), level)
+}
+
+// BOOL TessPageIteratorIsAtBeginningOf(const TessPageIterator* handle, TessPageIteratorLevel level);
+
+// IsAtBeginningOf returns true when the iterator is at the first element at given level, e.g. the first word of a
+// textline for RIL_TEXTLINE.
+func (r *ResultIterator) IsAtBeginningOf(level PageIteratorLevel) bool {
+	return gobool(C.TessPageIteratorIsAtBeginningOf(C.TessResultIteratorGetPageIteratorConst(r.ri), C.TessPageIteratorLevel(level)))
+}
+
+/* TessChoiceIterator* TessResultIteratorGetChoiceIterator(const TessResultIterator* handle);
+
+Returns a ChoiceIterator for the symbol the ResultIterator points to.
+Available since tesseract 3.04.
+*/
+
+// ChoiceIterator returns an iterator over the alternative choices for the symbol (RIL_SYMBOL) the iterator points to.
+// The iterator starts at the best choice, the one that is returned by Text(RIL_SYMBOL).
+func (r *ResultIterator) ChoiceIterator() (*ChoiceIterator, error) {
+	ci := C.TessResultIteratorGetChoiceIterator(r.ri)
+	if ci == nil {
+		return nil, errors.New("no choices")
+	}
+
+	choiceIterator := &ChoiceIterator{
+		ci: ci,
+	}
+
+	runtime.SetFinalizer(choiceIterator, (*ChoiceIterator).delete)
+	return choiceIterator, nil
+}
+
+// Choices returns all choices for the symbol (RIL_SYMBOL) the iterator points to, starting with the best choice.
+func (r *ResultIterator) Choices() ([]Choice, error) {
+	ci, err := r.ChoiceIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer ci.Close()
+
+	choices := make([]Choice, 0)
+	for {
+		choices = append(choices, Choice{
+			Text:       ci.Text(),
+			Confidence: ci.Confidence(),
+		})
+		if !ci.Next() {
+			return choices, nil
+		}
+	}
+}
+
+// typedef struct TessChoiceIterator TessChoiceIterator;
+type ChoiceIterator struct {
+	ci *C.TessChoiceIterator
+}
+
+// void TessChoiceIteratorDelete(TessChoiceIterator* handle);
+func (c *ChoiceIterator) delete() {
+	if c.ci != nil {
+		C.TessChoiceIteratorDelete(c.ci)
+	}
+}
+
+// Close clears the choice iterator from memory
+func (c *ChoiceIterator) Close() {
+	c.delete()
+	c.ci = nil
+}
+
+// BOOL TessChoiceIteratorNext(TessChoiceIterator* handle);
+
+// Next moves to the next choice, it returns false when there are no more choices.
+func (c *ChoiceIterator) Next() bool {
+	return gobool(C.TessChoiceIteratorNext(c.ci))
+}
+
+// const char* TessChoiceIteratorGetUTF8Text(const TessChoiceIterator* handle);
+
+// Text returns the text of the current choice.
+func (c *ChoiceIterator) Text() string {
+	// the returned string is owned by the iterator and must not be freed
+	return C.GoString(C.TessChoiceIteratorGetUTF8Text(c.ci))
+}
+
+// float TessChoiceIteratorConfidence(const TessChoiceIterator* handle);
+
+// Confidence returns the confidence (between 0 and 100) of the current choice.
+func (c *ChoiceIterator) Confidence() float32 {
+	return float32(C.TessChoiceIteratorConfidence(c.ci))
+}
+
+// BOOL TessBaseAPIGetTextDirection(TessBaseAPI* handle, int* out_offset, float* out_slope);
+
+// TextDirection returns tesseract's estimate of the baseline of the text in the image set with SetImagePix.
+// The baseline is y = offset + slope*x in image coordinates, with y pointing down. ok is false when no estimate could
+// be made. This runs layout analysis, so it can be used before Recognize.
+func (t *Tess) TextDirection() (offset int, slope float32, ok bool) {
+	bounds, err := t.imageBounds()
+	if err != nil {
+		return 0, 0, false
+	}
+	var cOffset C.int
+	var cSlope C.float
+	var res C.int
+	t.capture(func() {
+		res = C.TessBaseAPIGetTextDirection(t.tba, &cOffset, &cSlope)
+	})
+	if !gobool(res) {
+		return 0, 0, false
+	}
+
+	// tesseract returns the baseline in the bottom-up coordinates of the recognized area, with the origin in its
+	// bottom left corner
+	area := bounds
+	if !t.rect.Empty() {
+		area = t.rect.Intersect(bounds)
+	}
+	slope = -float32(cSlope)
+	offset = area.Max.Y - int(cOffset) - int(math.Round(float64(slope)*float64(area.Min.X)))
+	return offset, slope, true
+}
+
+/* TessPageIterator* TessBaseAPIAnalyseLayout(TessBaseAPI* handle);
+
+Runs page layout analysis in the mode set by SetPageSegMode.
+May optionally be called prior to Recognize to get access to just
+the page layout results. Returns an iterator to the results.
+Returns NULL on error or an empty page.
+*/
+
+// AnalyseLayout runs page layout analysis in the mode set by SetPageSegMode and returns an iterator to the results.
+// This can be called before Recognize to get just the page layout.
+func (t *Tess) AnalyseLayout() (*PageIterator, error) {
+	var pi *C.TessPageIterator
+	t.capture(func() {
+		pi = C.TessBaseAPIAnalyseLayout(t.tba)
+	})
+	if pi == nil {
+		return nil, errors.New("no layout")
+	}
+
+	pageIterator := &PageIterator{
+		pi: pi,
+	}
+
+	runtime.SetFinalizer(pageIterator, (*PageIterator).delete)
+	return pageIterator, nil
+}
+
+type PageIterator struct {
+	pi *C.TessPageIterator
+}
+
+// void TessPageIteratorDelete(TessPageIterator* handle);
+func (p *PageIterator) delete() {
+	if p.pi != nil {
+		C.TessPageIteratorDelete(p.pi)
+	}
+}
+
+// Close clears the page iterator from memory
+func (p *PageIterator) Close() {
+	p.delete()
+	p.pi = nil
+}
+
+// BOOL TessPageIteratorNext(TessPageIterator* handle, TessPageIteratorLevel level);
+func (p *PageIterator) Next(level PageIteratorLevel) bool {
+	return gobool(C.TessPageIteratorNext(p.pi, C.TessPageIteratorLevel(level)))
+}
+
+// BoundingBox returns the bounding box of the current element at given level, in image coordinates.
+// ok is false when the iterator is at the end.
+func (p *PageIterator) BoundingBox(level PageIteratorLevel) (rect image.Rectangle, ok bool) {
+	return boundingBox(p.pi, level)
+}
+
+/* BOOL TessPageIteratorBoundingBox(const TessPageIterator* handle, TessPageIteratorLevel level, int* left, int* top, int* right, int* bottom);
+
+Returns the bounding rectangle of the current object at the given level.
+The returned bounding box is guaranteed to match the size and position
+of the image returned by GetBinaryImage, but may clip foreground pixels
+from a grey image.
+Returns false if there is no such object at the current position.
+*/
+
+// boundingBox is used by both PageIterator and ResultIterator
+func boundingBox(pi *C.TessPageIterator, level PageIteratorLevel) (image.Rectangle, bool) {
+	var left, top, right, bottom C.int
+	ok := gobool(C.TessPageIteratorBoundingBox(pi, C.TessPageIteratorLevel(level), &left, &top, &right, &bottom))
+	return image.Rect(int(left), int(top), int(right), int(bottom)), ok
+}
+
+/* void TessPageIteratorOrientation(TessPageIterator* handle, TessOrientation *orientation, TessWritingDirection *writing_direction, TessTextlineOrder *textline_order, float *deskew_angle);
+
+Returns orientation for the block the iterator points to.
+  orientation, writing_direction, textline_order: see enum definitions
+  deskew_angle: after rotating the block so the text orientation is
+                upright, how many radians does one have to rotate the
+                block anti-clockwise for it to be level?
+                  -Pi/4 <= deskew_angle <= Pi/4
+*/
+
+// Orientation returns the orientation of the block the iterator points to.
+// deskewAngle is the angle in radians to rotate the upright block anti-clockwise for it to be level.
+func (p *PageIterator) Orientation() (orientation PageOrientation, writingDirection WritingDirection, textlineOrder TextlineOrder, deskewAngle float32) {
+	var cOrientation C.TessOrientation
+	var cWritingDirection C.TessWritingDirection
+	var cTextlineOrder C.TessTextlineOrder
+	var cDeskewAngle C.float
+	C.TessPageIteratorOrientation(p.pi, &cOrientation, &cWritingDirection, &cTextlineOrder, &cDeskewAngle)
+	return PageOrientation(cOrientation), WritingDirection(cWritingDirection), TextlineOrder(cTextlineOrder), float32(cDeskewAngle)
+}
+
 // typedef struct TessMutableIterator TessMutableIterator;
-// typedef enum TessOcrEngineMode { OEM_TESSERACT_ONLY, OEM_CUBE_ONLY, OEM_TESSERACT_CUBE_COMBINED, OEM_DEFAULT } TessOcrEngineMode;
 // typedef enum TessPageSegMode { PSM_OSD_ONLY, PSM_AUTO_OSD, PSM_AUTO_ONLY, PSM_AUTO, PSM_SINGLE_COLUMN, PSM_SINGLE_BLOCK_VERT_TEXT, PSM_SINGLE_BLOCK, PSM_SINGLE_LINE, PSM_SINGLE_WORD, PSM_CIRCLE_WORD, PSM_SINGLE_CHAR, PSM_COUNT } TessPageSegMode;
 // typedef enum TessPageIteratorLevel { RIL_BLOCK, RIL_PARA, RIL_TEXTLINE, RIL_WORD, RIL_SYMBOL} TessPageIteratorLevel;
 // typedef enum TessPolyBlockType { PT_UNKNOWN, PT_FLOWING_TEXT, PT_HEADING_TEXT, PT_PULLOUT_TEXT, PT_TABLE, PT_VERTICAL_TEXT, PT_CAPTION_TEXT, PT_FLOWING_IMAGE, PT_HEADING_IMAGE, PT_PULLOUT_IMAGE, PT_HORZ_LINE, PT_VERT_LINE, PT_NOISE, PT_COUNT } TessPolyBlockType;
-// typedef enum TessOrientation { ORIENTATION_PAGE_UP, ORIENTATION_PAGE_RIGHT, ORIENTATION_PAGE_DOWN, ORIENTATION_PAGE_LEFT } TessOrientation;
-// typedef enum TessWritingDirection { WRITING_DIRECTION_LEFT_TO_RIGHT, WRITING_DIRECTION_RIGHT_TO_LEFT, WRITING_DIRECTION_TOP_TO_BOTTOM } TessWritingDirection;
-// typedef enum TessTextlineOrder { TEXTLINE_ORDER_LEFT_TO_RIGHT, TEXTLINE_ORDER_RIGHT_TO_LEFT, TEXTLINE_ORDER_TOP_TO_BOTTOM } TessTextlineOrder;
 // typedef struct ETEXT_DESC ETEXT_DESC;
 // typedef struct Pix PIX;
 // typedef struct Boxa BOXA;
@@ -446,89 +1070,39 @@ func (r *ResultIterator) Text(level PageIteratorLevel) (string, error) {
 
 // BOOL TessBaseAPISetDebugVariable(TessBaseAPI* handle, const char* name, const char* value);
 
-// BOOL TessBaseAPIGetIntVariable( const TessBaseAPI* handle, const char* name, int* value);
-// BOOL TessBaseAPIGetBoolVariable( const TessBaseAPI* handle, const char* name, BOOL* value);
-// BOOL TessBaseAPIGetDoubleVariable(const TessBaseAPI* handle, const char* name, double* value);
-// const char* TessBaseAPIGetStringVariable(const TessBaseAPI* handle, const char* name);
-
 // void TessBaseAPIPrintVariables( const TessBaseAPI* handle, FILE* fp);
 // BOOL TessBaseAPIPrintVariablesToFile(const TessBaseAPI* handle, const char* filename);
 
-// int TessBaseAPIInit1(TessBaseAPI* handle, const char* datapath, const char* language, TessOcrEngineMode oem, char** configs, int configs_size);
 // int TessBaseAPIInit2(TessBaseAPI* handle, const char* datapath, const char* language, TessOcrEngineMode oem);
 
 // int TessBaseAPIInitLangMod(TessBaseAPI* handle, const char* datapath, const char* language);
 // void TessBaseAPIInitForAnalysePage(TessBaseAPI* handle);
 
-// void TessBaseAPIReadConfigFile(TessBaseAPI* handle, const char* filename);
-// void TessBaseAPIReadDebugConfigFile(TessBaseAPI* handle, const char* filename);
-
-// void TessBaseAPISetPageSegMode(TessBaseAPI* handle, TessPageSegMode mode);
-// TessPageSegMode TessBaseAPIGetPageSegMode(const TessBaseAPI* handle);
-
-// char* TessBaseAPIRect(TessBaseAPI* handle, const unsigned char* imagedata, int bytes_per_pixel, int bytes_per_line, int left, int top, int width, int height);
-
-// void TessBaseAPIClearAdaptiveClassifier(TessBaseAPI* handle);
-
-// void TessBaseAPISetImage(TessBaseAPI* handle, const unsigned char* imagedata, int width, int height, int bytes_per_pixel, int bytes_per_line);
-
-// void TessBaseAPISetSourceResolution(TessBaseAPI* handle, int ppi);
-
-// PIX* TessBaseAPIGetThresholdedImage( TessBaseAPI* handle);
-// BOXA* TessBaseAPIGetRegions( TessBaseAPI* handle, PIXA** pixa);
-// BOXA* TessBaseAPIGetTextlines( TessBaseAPI* handle, PIXA** pixa, int** blockids);
-// BOXA* TessBaseAPIGetStrips( TessBaseAPI* handle, PIXA** pixa, int** blockids);
-// BOXA* TessBaseAPIGetWords( TessBaseAPI* handle, PIXA** pixa);
-// BOXA* TessBaseAPIGetConnectedComponents(TessBaseAPI* handle, PIXA** cc);
-// BOXA* TessBaseAPIGetComponentImages( TessBaseAPI* handle, TessPageIteratorLevel level, BOOL text_only, PIXA** pixa, int** blockids);
-
-// int TessBaseAPIGetThresholdedImageScaleFactor(const TessBaseAPI* handle);
-
 // void TessBaseAPIDumpPGM(TessBaseAPI* handle, const char* filename);
 
-// TessPageIterator* TessBaseAPIAnalyseLayout(TessBaseAPI* handle);
-
 // int TessBaseAPIRecognizeForChopTest(TessBaseAPI* handle, ETEXT_DESC* monitor);
-// char* TessBaseAPIProcessPages(TessBaseAPI* handle, const char* filename, const char* retry_config, int timeout_millisec);
 // char* TessBaseAPIProcessPage(TessBaseAPI* handle, PIX* pix, int page_index, const char* filename, const char* retry_config, int timeout_millisec);
 
 // TessMutableIterator* TessBaseAPIGetMutableIterator(TessBaseAPI* handle);
 
-// int TessBaseAPIMeanTextConf(TessBaseAPI* handle);
-// int* TessBaseAPIAllWordConfidences(TessBaseAPI* handle);
-// BOOL TessBaseAPIAdaptToWordStr(TessBaseAPI* handle, TessPageSegMode mode, const char* wordstr);
-
-// int TessBaseAPIIsValidWord(TessBaseAPI* handle, const char *word);
-// BOOL TessBaseAPIGetTextDirection(TessBaseAPI* handle, int* out_offset, float* out_slope);
-
 // const char* TessBaseAPIGetUnichar(TessBaseAPI* handle, int unichar_id);
 // void TessBaseAPISetMinOrientationMargin(TessBaseAPI* handle, double margin);
 
 // /* Page iterator */
-// void TessPageIteratorDelete(TessPageIterator* handle);
 // TessPageIterator* TessPageIteratorCopy(const TessPageIterator* handle);
 // void TessPageIteratorBegin(TessPageIterator* handle);
-// BOOL TessPageIteratorNext(TessPageIterator* handle, TessPageIteratorLevel level);
-// BOOL TessPageIteratorIsAtBeginningOf(const TessPageIterator* handle, TessPageIteratorLevel level);
 // BOOL TessPageIteratorIsAtFinalElement(const TessPageIterator* handle, TessPageIteratorLevel level,
 // TessPageIteratorLevel element);
-// BOOL TessPageIteratorBoundingBox(const TessPageIterator* handle, TessPageIteratorLevel level,
-// int* left, int* top, int* right, int* bottom);
 // TessPolyBlockType TessPageIteratorBlockType(const TessPageIterator* handle);
 // PIX* TessPageIteratorGetBinaryImage(const TessPageIterator* handle, TessPageIteratorLevel level);
 // PIX* TessPageIteratorGetImage(const TessPageIterator* handle, TessPageIteratorLevel level, int padding, int* left, int* top);
 // BOOL TessPageIteratorBaseline(const TessPageIterator* handle, TessPageIteratorLevel level, int* x1, int* y1, int* x2, int* y2);
-// void TessPageIteratorOrientation(TessPageIterator* handle, TessOrientation *orientation, TessWritingDirection *writing_direction, TessTextlineOrder *textline_order, float *deskew_angle);
 
 // /* Result iterator */
 // TessResultIterator* TessResultIteratorCopy(const TessResultIterator* handle);
 // TessPageIterator* TessResultIteratorGetPageIterator(TessResultIterator* handle);
-// const TessPageIterator* TessResultIteratorGetPageIteratorConst(const TessResultIterator* handle);
 // char* TessResultIteratorGetUTF8Text(const TessResultIterator* handle, TessPageIteratorLevel level);
-// float TessResultIteratorConfidence(const TessResultIterator* handle, TessPageIteratorLevel level);
 // const char* TessResultIteratorWordFontAttributes(const TessResultIterator* handle, BOOL* is_bold, BOOL* is_italic, BOOL* is_underlined, BOOL* is_monospace, BOOL* is_serif, BOOL* is_smallcaps, int* pointsize, int* font_id);
-// BOOL TessResultIteratorWordIsFromDictionary(const TessResultIterator* handle);
-// BOOL TessResultIteratorWordIsNumeric(const TessResultIterator* handle);
 // BOOL TessResultIteratorSymbolIsSuperscript(const TessResultIterator* handle);
 // BOOL TessResultIteratorSymbolIsSubscript(const TessResultIterator* handle);
 // BOOL TessResultIteratorSymbolIsDropcap(const TessResultIterator* handle);
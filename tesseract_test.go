@@ -0,0 +1,307 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"flag"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"gopkg.in/GeertJohan/go.leptonica.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1/eval"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// goldenTolerance is the error rate that is accepted, so small differences between tesseract builds don't fail the tests
+const goldenTolerance = 0.02
+
+// goldenBoxDistance is the number of pixels a box may be off before the character counts as an error
+const goldenBoxDistance = 2
+
+var goldenImages = []string{
+	"FelixScan.jpg",
+	"differentFonts.png",
+	"getobMetWob.png",
+}
+
+// newTestTess creates a Tess for eng, the test is skipped when tesseract or the eng traineddata is not available.
+func newTestTess(tb testing.TB) *Tess {
+	prefix := os.Getenv("TESSDATA_PREFIX")
+	if prefix == "" {
+		prefix = "/usr/local/share"
+	}
+	t, err := NewTess(filepath.Join(prefix, "tessdata"), "eng")
+	if err != nil {
+		tb.Skipf("tesseract is not available: %s", err)
+	}
+	return t
+}
+
+// libraryVersion returns the version of libtesseract, e.g. 5.3.0.
+func libraryVersion() string {
+	v := Version()
+	return v[strings.LastIndex(v, ":")+1:]
+}
+
+// checkGoldenVersion records the tesseract version in the golden directory when updating, otherwise it fails when the
+// golden files are missing and logs when they were made with another version.
+func checkGoldenVersion(t *testing.T) {
+	filename := filepath.Join("testdata", "golden", "VERSION")
+	if *update {
+		err := os.MkdirAll(filepath.Dir(filename), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(filename, []byte(libraryVersion()+"\n"), 0644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("golden files are missing, see testdata/golden/README.md: %s", err)
+	}
+	if version := strings.TrimSpace(string(data)); version != libraryVersion() {
+		t.Logf("golden files were made with tesseract %s, testing with %s", version, libraryVersion())
+	}
+}
+
+func TestGolden(t *testing.T) {
+	tess := newTestTess(t)
+	defer tess.Close()
+	checkGoldenVersion(t)
+
+	for _, name := range goldenImages {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			pix, err := leptonica.NewPixFromFile(filepath.Join("tessexample", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer pix.Close()
+
+			tess.SetPageSegMode(PSM_AUTO)
+			tess.SetImagePix(pix)
+			err = tess.Recognize()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkGolden(t, name+".txt", tess.Text(), textErrorRate)
+			checkGolden(t, name+".box", tess.BoxTextRaw(0), boxErrorRate)
+			checkGolden(t, name+".hocr", tess.HOCRText(0), hocrErrorRate)
+		})
+	}
+}
+
+// checkGolden compares got to the golden file with errorRate, or updates the golden file when -update is set.
+func checkGolden(t *testing.T, filename string, got string, errorRate func(want, got string) float64) {
+	filename = filepath.Join("testdata", "golden", filename)
+	if *update {
+		err := os.MkdirAll(filepath.Dir(filename), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(filename, []byte(got), 0644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Errorf("%s, run go test -run TestGolden -update to create it", err)
+		return
+	}
+	if rate := errorRate(string(want), got); rate > goldenTolerance {
+		t.Errorf("%s: error rate %.3f exceeds %.3f, run go test -update when the change is expected", filename, rate, goldenTolerance)
+	}
+}
+
+// textErrorRate returns the character error rate of got.
+func textErrorRate(want, got string) float64 {
+	return eval.Compare(want, got).CER()
+}
+
+// boxErrorRate returns the fraction of characters that were not recognized or have a box that differs more than
+// goldenBoxDistance pixels.
+func boxErrorRate(want, got string) float64 {
+	wantBoxes, err := ParseBoxText(want)
+	if err != nil {
+		return 1
+	}
+	gotBoxes, err := ParseBoxText(got)
+	if err != nil {
+		return 1
+	}
+	if len(wantBoxes.Characters) == 0 {
+		if len(gotBoxes.Characters) == 0 {
+			return 0
+		}
+		return 1
+	}
+
+	// align the characters, and compare the boxes of the characters that match
+	wantChars := boxCharacters(wantBoxes)
+	gotChars := boxCharacters(gotBoxes)
+	errors := 0
+	i, j := 0, 0
+	for _, e := range eval.Align(wantChars, gotChars) {
+		switch e.Op {
+		case eval.Match:
+			if !boxesClose(wantBoxes.Characters[i], gotBoxes.Characters[j]) {
+				errors++
+			}
+			i++
+			j++
+		case eval.Substitution:
+			errors++
+			i++
+			j++
+		case eval.Deletion:
+			errors++
+			i++
+		case eval.Insertion:
+			errors++
+			j++
+		}
+	}
+	return float64(errors) / float64(len(wantChars))
+}
+
+func boxCharacters(bt *BoxText) []string {
+	chars := make([]string, 0, len(bt.Characters))
+	for _, c := range bt.Characters {
+		chars = append(chars, string(c.Character))
+	}
+	return chars
+}
+
+func boxesClose(a, b BoxCharacter) bool {
+	return distance(a.StartX, b.StartX) <= goldenBoxDistance && distance(a.StartY, b.StartY) <= goldenBoxDistance &&
+		distance(a.EndX, b.EndX) <= goldenBoxDistance && distance(a.EndY, b.EndY) <= goldenBoxDistance
+}
+
+func distance(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+var hocrTag = regexp.MustCompile(`<[^>]*>`)
+
+// hocrErrorRate returns the character error rate of the text in the hOCR, the markup differs too much between tesseract
+// versions to compare it directly.
+func hocrErrorRate(want, got string) float64 {
+	return textErrorRate(html.UnescapeString(hocrTag.ReplaceAllString(want, " ")), html.UnescapeString(hocrTag.ReplaceAllString(got, " ")))
+}
+
+// loadTestImages loads the example images from tessexample.
+func loadTestImages(b *testing.B) map[string]*leptonica.Pix {
+	images := make(map[string]*leptonica.Pix, len(goldenImages))
+	for _, name := range goldenImages {
+		pix, err := leptonica.NewPixFromFile(filepath.Join("tessexample", name))
+		if err != nil {
+			b.Fatal(err)
+		}
+		images[name] = pix
+	}
+	return images
+}
+
+func closeTestImages(images map[string]*leptonica.Pix) {
+	for _, pix := range images {
+		pix.Close()
+	}
+}
+
+func BenchmarkNewTess(b *testing.B) {
+	newTestTess(b).Close()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newTestTess(b).Close()
+	}
+}
+
+func BenchmarkRecognize(b *testing.B) {
+	tess := newTestTess(b)
+	defer tess.Close()
+	images := loadTestImages(b)
+	defer closeTestImages(images)
+
+	for _, name := range goldenImages {
+		pix := images[name]
+		b.Run(name, func(b *testing.B) {
+			var total Stats
+			for i := 0; i < b.N; i++ {
+				_, stats, err := tess.RecognizeWithStats(pix)
+				if err != nil {
+					b.Fatal(err)
+				}
+				total.SetImage += stats.SetImage
+				total.AnalyseLayout += stats.AnalyseLayout
+				total.Recognize += stats.Recognize
+				total.Text += stats.Text
+				total.CHeapGrowth += stats.CHeapGrowth
+			}
+			n := float64(b.N)
+			b.ReportMetric(float64(total.SetImage)/n, "setimage-ns/op")
+			b.ReportMetric(float64(total.AnalyseLayout)/n, "layout-ns/op")
+			b.ReportMetric(float64(total.Recognize)/n, "recognize-ns/op")
+			b.ReportMetric(float64(total.Text)/n, "text-ns/op")
+			b.ReportMetric(float64(total.CHeapGrowth)/n, "cheap-B/op")
+		})
+	}
+}
+
+// benchmarkOutput measures producing an output format from recognition results, recognition itself is not measured.
+func benchmarkOutput(b *testing.B, output func(t *Tess)) {
+	tess := newTestTess(b)
+	defer tess.Close()
+	images := loadTestImages(b)
+	defer closeTestImages(images)
+
+	for _, name := range goldenImages {
+		pix := images[name]
+		b.Run(name, func(b *testing.B) {
+			tess.SetImagePix(pix)
+			err := tess.Recognize()
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				output(tess)
+			}
+		})
+	}
+}
+
+func BenchmarkText(b *testing.B) {
+	benchmarkOutput(b, func(t *Tess) { t.Text() })
+}
+
+func BenchmarkHOCRText(b *testing.B) {
+	benchmarkOutput(b, func(t *Tess) { t.HOCRText(0) })
+}
+
+func BenchmarkBoxText(b *testing.B) {
+	benchmarkOutput(b, func(t *Tess) { t.BoxTextRaw(0) })
+}
+
+func BenchmarkUNLVText(b *testing.B) {
+	benchmarkOutput(b, func(t *Tess) { t.UNLVText() })
+}
+
+func BenchmarkWords(b *testing.B) {
+	benchmarkOutput(b, func(t *Tess) { t.Words() })
+}
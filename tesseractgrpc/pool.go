@@ -0,0 +1,83 @@
+//go:build cgo
+// +build cgo
+
+package tesseractgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+)
+
+// PoolBackend is a Backend with a tesseract.Pool for each language.
+type PoolBackend struct {
+	pools     map[string]*tesseract.Pool
+	languages []string
+}
+
+var _ Backend = (*PoolBackend)(nil)
+
+// NewPoolBackend creates a pool of at most size instances for each of languages. One instance of each pool is
+// created right away, so invalid languages are reported by NewPoolBackend.
+func NewPoolBackend(datapath string, languages []string, size int) (*PoolBackend, error) {
+	b := &PoolBackend{
+		pools: make(map[string]*tesseract.Pool),
+	}
+	for _, language := range languages {
+		language = strings.TrimSpace(language)
+		if language == "" || b.pools[language] != nil {
+			continue
+		}
+		lang := language
+		pool := tesseract.NewPool(size, func() (*tesseract.Tess, error) {
+			return tesseract.NewTess(datapath, lang)
+		})
+		t, err := pool.Get(context.Background())
+		if err != nil {
+			// pool isn't in b.pools yet
+			pool.Close()
+			b.Close()
+			return nil, fmt.Errorf("error initializing tesseract for %s: %s", language, err)
+		}
+		pool.Put(t)
+		b.pools[language] = pool
+		b.languages = append(b.languages, language)
+	}
+	if len(b.languages) == 0 {
+		return nil, errors.New("no languages")
+	}
+	return b, nil
+}
+
+// Languages implements Backend.
+func (b *PoolBackend) Languages() []string {
+	return append([]string(nil), b.languages...)
+}
+
+// Engine implements Backend. The page seg mode of the instance is restored when it is released.
+func (b *PoolBackend) Engine(ctx context.Context, language string) (tesseract.Engine, func(), error) {
+	pool, ok := b.pools[language]
+	if !ok {
+		return nil, nil, errors.New("unsupported language: " + language)
+	}
+	t, err := pool.Get(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	psm := t.PageSegMode()
+	release := func() {
+		t.SetPageSegMode(psm)
+		pool.Put(t)
+	}
+	return t, release, nil
+}
+
+// Close closes the pools.
+func (b *PoolBackend) Close() {
+	for _, pool := range b.pools {
+		pool.Close()
+	}
+}
@@ -0,0 +1,286 @@
+// Package tesseractgrpc implements the OCR gRPC service of package tesseractpb with tesseract engines.
+//
+// The server gets its engines from a Backend. PoolBackend provides pools of *tesseract.Tess, other backends (e.g. one
+// returning the scripted fake of package tesseracttest) make it possible to test clients without libtesseract.
+package tesseractgrpc
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+
+	// image formats accepted in requests
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1/tesseractpb"
+)
+
+// Backend provides the engines for the server.
+type Backend interface {
+	// Languages returns the languages that can be requested, the first language is used when none is requested.
+	Languages() []string
+	// Engine takes an engine for language, waiting until ctx is done when none is available. The engine is used for
+	// a single request or document and is given back with release, which must undo the page seg mode set on it.
+	Engine(ctx context.Context, language string) (engine tesseract.Engine, release func(), err error)
+}
+
+// pager is implemented by engines that provide the layout of the page, like *tesseract.Tess
+type pager interface {
+	Page() (*tesseract.Page, error)
+}
+
+// Server implements tesseractpb.OCRServer.
+type Server struct {
+	tesseractpb.UnimplementedOCRServer
+
+	backend Backend
+}
+
+var _ tesseractpb.OCRServer = (*Server)(nil)
+
+// NewServer creates a server that recognizes images with engines from backend.
+// Register it with tesseractpb.RegisterOCRServer.
+func NewServer(backend Backend) *Server {
+	return &Server{backend: backend}
+}
+
+// Recognize implements tesseractpb.OCRServer.
+func (s *Server) Recognize(ctx context.Context, req *tesseractpb.RecognizeRequest) (*tesseractpb.RecognizeResponse, error) {
+	engine, release, err := s.engine(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return recognize(engine, req, 0)
+}
+
+// RecognizeDocument implements tesseractpb.OCRServer.
+func (s *Server) RecognizeDocument(stream tesseractpb.OCR_RecognizeDocumentServer) error {
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	engine, release, err := s.engine(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	first := req
+	for pageNumber := 0; ; pageNumber++ {
+		if pageNumber > 0 {
+			if req.GetLanguage() != "" && req.GetLanguage() != first.GetLanguage() {
+				return status.Error(codes.InvalidArgument, "the language can't change within a document")
+			}
+			if req.GetPageSegMode() != tesseractpb.PageSegMode_PAGE_SEG_MODE_UNSPECIFIED && req.GetPageSegMode() != first.GetPageSegMode() {
+				return status.Error(codes.InvalidArgument, "the page seg mode can't change within a document")
+			}
+		}
+
+		resp, err := recognize(engine, req, pageNumber)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(resp)
+		if err != nil {
+			return err
+		}
+
+		req, err = stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ListLanguages implements tesseractpb.OCRServer.
+func (s *Server) ListLanguages(ctx context.Context, req *tesseractpb.ListLanguagesRequest) (*tesseractpb.ListLanguagesResponse, error) {
+	return &tesseractpb.ListLanguagesResponse{Languages: s.backend.Languages()}, nil
+}
+
+// engine takes an engine for the language of req and sets the page seg mode of req.
+func (s *Server) engine(ctx context.Context, req *tesseractpb.RecognizeRequest) (tesseract.Engine, func(), error) {
+	language, err := s.language(req.GetLanguage())
+	if err != nil {
+		return nil, nil, err
+	}
+	psm, set, err := pageSegMode(req.GetPageSegMode())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	engine, release, err := s.backend.Engine(ctx, language)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if set {
+		engine.SetPageSegMode(psm)
+	}
+	return engine, release, nil
+}
+
+// language returns the requested language, or the default language when none was requested.
+func (s *Server) language(requested string) (string, error) {
+	languages := s.backend.Languages()
+	if len(languages) == 0 {
+		return "", status.Error(codes.Unavailable, "no languages available")
+	}
+	if requested == "" {
+		return languages[0], nil
+	}
+	for _, language := range languages {
+		if language == requested {
+			return language, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unsupported language: %s", requested)
+}
+
+// pageSegMode converts psm to the tesseract page seg mode. set is false when psm is unspecified.
+func pageSegMode(psm tesseractpb.PageSegMode) (mode tesseract.PageSegMode, set bool, err error) {
+	if psm == tesseractpb.PageSegMode_PAGE_SEG_MODE_UNSPECIFIED {
+		return 0, false, nil
+	}
+	mode = tesseract.PageSegMode(psm - 1)
+	if mode < tesseract.PSM_OSD_ONLY || mode >= tesseract.PSM_COUNT {
+		return 0, false, status.Errorf(codes.InvalidArgument, "unknown page seg mode: %d", psm)
+	}
+	return mode, true, nil
+}
+
+// recognize recognizes the image of req with engine.
+func recognize(engine tesseract.Engine, req *tesseractpb.RecognizeRequest, pageNumber int) (*tesseractpb.RecognizeResponse, error) {
+	img, _, err := image.Decode(bytes.NewReader(req.GetImage()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not decode image: %s", err)
+	}
+	err = engine.SetImage(img)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if rect := req.GetRectangle(); rect != nil {
+		if rect.GetWidth() <= 0 || rect.GetHeight() <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "rectangle must have a positive width and height")
+		}
+		engine.SetRectangle(int(rect.GetLeft()), int(rect.GetTop()), int(rect.GetWidth()), int(rect.GetHeight()))
+	}
+
+	err = engine.Recognize()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "recognition failed: %s", err)
+	}
+
+	var page *tesseract.Page
+	if p, ok := engine.(pager); ok {
+		page, err = p.Page()
+	} else {
+		page, err = pageFromWords(engine, img.Bounds())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &tesseractpb.RecognizeResponse{
+		PageNumber:     int32(pageNumber),
+		Text:           engine.Text(),
+		MeanConfidence: int32(engine.MeanTextConfidence()),
+		Page:           toPage(page),
+	}
+	if req.GetHocr() {
+		// this is the ocr_page div of the page, not a complete hOCR document
+		resp.Hocr = engine.HOCRText(pageNumber)
+	}
+	return resp, nil
+}
+
+// pageFromWords returns a page with a single line of all words, for engines that don't provide the layout.
+func pageFromWords(engine tesseract.Engine, bounds image.Rectangle) (*tesseract.Page, error) {
+	words, err := engine.Words()
+	if err != nil {
+		return nil, err
+	}
+	page := &tesseract.Page{
+		Box:    image.Rect(0, 0, bounds.Dx(), bounds.Dy()),
+		Blocks: make([]tesseract.Block, 0, 1),
+	}
+	if len(words) == 0 {
+		return page, nil
+	}
+
+	box := words[0].Box
+	for _, word := range words[1:] {
+		box = box.Union(word.Box)
+	}
+	page.Blocks = append(page.Blocks, tesseract.Block{
+		Box: box,
+		Paragraphs: []tesseract.Paragraph{{
+			Box:   box,
+			Lines: []tesseract.Line{{Box: box, Words: words}},
+		}},
+	})
+	return page, nil
+}
+
+func toPage(page *tesseract.Page) *tesseractpb.Page {
+	pb := &tesseractpb.Page{
+		Box:    toBoundingBox(page.Box),
+		Blocks: make([]*tesseractpb.Block, 0, len(page.Blocks)),
+	}
+	for _, block := range page.Blocks {
+		pbBlock := &tesseractpb.Block{
+			Box:        toBoundingBox(block.Box),
+			Paragraphs: make([]*tesseractpb.Paragraph, 0, len(block.Paragraphs)),
+		}
+		for _, paragraph := range block.Paragraphs {
+			pbParagraph := &tesseractpb.Paragraph{
+				Box:   toBoundingBox(paragraph.Box),
+				Lines: make([]*tesseractpb.Line, 0, len(paragraph.Lines)),
+			}
+			for _, line := range paragraph.Lines {
+				pbLine := &tesseractpb.Line{
+					Box:   toBoundingBox(line.Box),
+					Words: make([]*tesseractpb.Word, 0, len(line.Words)),
+				}
+				for _, word := range line.Words {
+					pbLine.Words = append(pbLine.Words, &tesseractpb.Word{
+						Text:           word.Text,
+						Confidence:     int32(word.Confidence),
+						Box:            toBoundingBox(word.Box),
+						FromDictionary: word.FromDictionary,
+						Numeric:        word.Numeric,
+					})
+				}
+				pbParagraph.Lines = append(pbParagraph.Lines, pbLine)
+			}
+			pbBlock.Paragraphs = append(pbBlock.Paragraphs, pbParagraph)
+		}
+		pb.Blocks = append(pb.Blocks, pbBlock)
+	}
+	return pb
+}
+
+func toBoundingBox(rect image.Rectangle) *tesseractpb.BoundingBox {
+	return &tesseractpb.BoundingBox{
+		MinX: int32(rect.Min.X),
+		MinY: int32(rect.Min.Y),
+		MaxX: int32(rect.Max.X),
+		MaxY: int32(rect.Max.Y),
+	}
+}
@@ -0,0 +1,189 @@
+package tesseractgrpc
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+	"gopkg.in/GeertJohan/go.tesseract.v1/tesseractpb"
+	"gopkg.in/GeertJohan/go.tesseract.v1/tesseracttest"
+)
+
+// fakeBackend hands out a single scripted engine
+type fakeBackend struct {
+	languages []string
+	engine    *tesseracttest.Engine
+	released  int
+}
+
+func (b *fakeBackend) Languages() []string {
+	return b.languages
+}
+
+func (b *fakeBackend) Engine(ctx context.Context, language string) (tesseract.Engine, func(), error) {
+	return b.engine, func() { b.released++ }, nil
+}
+
+// newTestClient starts a server with backend on an in-memory listener and returns a client connected to it.
+func newTestClient(t *testing.T, backend Backend) tesseractpb.OCRClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	tesseractpb.RegisterOCRServer(server, NewServer(backend))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return tesseractpb.NewOCRClient(conn)
+}
+
+func testImage(t *testing.T) []byte {
+	buf := &bytes.Buffer{}
+	err := png.Encode(buf, image.NewGray(image.Rect(0, 0, 200, 50)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRecognize(t *testing.T) {
+	backend := &fakeBackend{
+		languages: []string{"eng", "nld"},
+		engine: tesseracttest.New(tesseracttest.Document{
+			Text:       "hello world\n",
+			HOCR:       "<div class='ocr_page'></div>",
+			Confidence: 90,
+			Words:      tesseracttest.WordsFromText("hello world", 90),
+		}),
+	}
+	client := newTestClient(t, backend)
+
+	resp, err := client.Recognize(context.Background(), &tesseractpb.RecognizeRequest{
+		Image:       testImage(t),
+		Language:    "nld",
+		PageSegMode: tesseractpb.PageSegMode_PAGE_SEG_MODE_SINGLE_LINE,
+		Rectangle:   &tesseractpb.Rectangle{Left: 10, Top: 5, Width: 100, Height: 20},
+		Hocr:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetText() != "hello world\n" || resp.GetMeanConfidence() != 90 || resp.GetHocr() == "" {
+		t.Errorf("unexpected response: %v", resp)
+	}
+	words := resp.GetPage().GetBlocks()[0].GetParagraphs()[0].GetLines()[0].GetWords()
+	if len(words) != 2 || words[1].GetText() != "world" || words[1].GetConfidence() != 90 {
+		t.Errorf("unexpected words: %v", words)
+	}
+	if box := resp.GetPage().GetBox(); box.GetMaxX() != 200 || box.GetMaxY() != 50 {
+		t.Errorf("unexpected page box: %v", box)
+	}
+
+	engine := backend.engine
+	if engine.PageSegMode != tesseract.PSM_SINGLE_LINE {
+		t.Errorf("expected PSM_SINGLE_LINE, got %s", engine.PageSegMode)
+	}
+	if engine.Rectangle != image.Rect(10, 5, 110, 25) {
+		t.Errorf("unexpected rectangle: %v", engine.Rectangle)
+	}
+	if backend.released != 1 {
+		t.Errorf("expected the engine to be released once, got %d", backend.released)
+	}
+}
+
+func TestRecognizeErrors(t *testing.T) {
+	backend := &fakeBackend{
+		languages: []string{"eng"},
+		engine:    tesseracttest.New(),
+	}
+	client := newTestClient(t, backend)
+
+	tests := []struct {
+		name string
+		req  *tesseractpb.RecognizeRequest
+		code codes.Code
+	}{
+		{"language", &tesseractpb.RecognizeRequest{Image: testImage(t), Language: "deu"}, codes.InvalidArgument},
+		{"page seg mode", &tesseractpb.RecognizeRequest{Image: testImage(t), PageSegMode: 42}, codes.InvalidArgument},
+		{"image", &tesseractpb.RecognizeRequest{Image: []byte("not an image")}, codes.InvalidArgument},
+		{"rectangle", &tesseractpb.RecognizeRequest{Image: testImage(t), Rectangle: &tesseractpb.Rectangle{}}, codes.InvalidArgument},
+		{"recognition", &tesseractpb.RecognizeRequest{Image: testImage(t)}, codes.Internal},
+	}
+	for _, test := range tests {
+		_, err := client.Recognize(context.Background(), test.req)
+		if status.Code(err) != test.code {
+			t.Errorf("%s: expected %s, got %v", test.name, test.code, err)
+		}
+	}
+}
+
+func TestRecognizeDocument(t *testing.T) {
+	backend := &fakeBackend{
+		languages: []string{"eng"},
+		engine: tesseracttest.New(
+			tesseracttest.Document{Text: "page one\n", Words: tesseracttest.WordsFromText("page one", 80)},
+			tesseracttest.Document{Text: "page two\n", Words: tesseracttest.WordsFromText("page two", 70)},
+		),
+	}
+	client := newTestClient(t, backend)
+
+	stream, err := client.RecognizeDocument(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"page one\n", "page two\n"}
+	for i, text := range expected {
+		err = stream.Send(&tesseractpb.RecognizeRequest{Image: testImage(t)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetPageNumber() != int32(i) || resp.GetText() != text {
+			t.Errorf("page %d: unexpected response: %v", i, resp)
+		}
+	}
+	err = stream.CloseSend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	if err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+	if backend.released != 1 {
+		t.Errorf("expected the engine to be released once, got %d", backend.released)
+	}
+}
+
+func TestListLanguages(t *testing.T) {
+	client := newTestClient(t, &fakeBackend{languages: []string{"eng", "nld"}})
+	resp, err := client.ListLanguages(context.Background(), &tesseractpb.ListLanguagesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if languages := resp.GetLanguages(); len(languages) != 2 || languages[0] != "eng" || languages[1] != "nld" {
+		t.Errorf("unexpected languages: %v", languages)
+	}
+}
@@ -0,0 +1,5 @@
+// Package tesseractpb holds the protobuf messages and the gRPC service of the OCR service, generated from ocr.proto.
+// The service is implemented by package tesseractgrpc.
+package tesseractpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative ocr.proto
@@ -0,0 +1,917 @@
+// OCR service backed by go.tesseract. The messages mirror the document model of the tesseract package: a page has
+// blocks of paragraphs of lines of words.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: ocr.proto
+
+package tesseractpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PageSegMode is tesseract's page seg mode. The values are the tesseract values plus one, so unspecified uses the
+// server's default.
+type PageSegMode int32
+
+const (
+	PageSegMode_PAGE_SEG_MODE_UNSPECIFIED            PageSegMode = 0
+	PageSegMode_PAGE_SEG_MODE_OSD_ONLY               PageSegMode = 1
+	PageSegMode_PAGE_SEG_MODE_AUTO_OSD               PageSegMode = 2
+	PageSegMode_PAGE_SEG_MODE_AUTO_ONLY              PageSegMode = 3
+	PageSegMode_PAGE_SEG_MODE_AUTO                   PageSegMode = 4
+	PageSegMode_PAGE_SEG_MODE_SINGLE_COLUMN          PageSegMode = 5
+	PageSegMode_PAGE_SEG_MODE_SINGLE_BLOCK_VERT_TEXT PageSegMode = 6
+	PageSegMode_PAGE_SEG_MODE_SINGLE_BLOCK           PageSegMode = 7
+	PageSegMode_PAGE_SEG_MODE_SINGLE_LINE            PageSegMode = 8
+	PageSegMode_PAGE_SEG_MODE_SINGLE_WORD            PageSegMode = 9
+	PageSegMode_PAGE_SEG_MODE_CIRCLE_WORD            PageSegMode = 10
+	PageSegMode_PAGE_SEG_MODE_SINGLE_CHAR            PageSegMode = 11
+)
+
+// Enum value maps for PageSegMode.
+var (
+	PageSegMode_name = map[int32]string{
+		0:  "PAGE_SEG_MODE_UNSPECIFIED",
+		1:  "PAGE_SEG_MODE_OSD_ONLY",
+		2:  "PAGE_SEG_MODE_AUTO_OSD",
+		3:  "PAGE_SEG_MODE_AUTO_ONLY",
+		4:  "PAGE_SEG_MODE_AUTO",
+		5:  "PAGE_SEG_MODE_SINGLE_COLUMN",
+		6:  "PAGE_SEG_MODE_SINGLE_BLOCK_VERT_TEXT",
+		7:  "PAGE_SEG_MODE_SINGLE_BLOCK",
+		8:  "PAGE_SEG_MODE_SINGLE_LINE",
+		9:  "PAGE_SEG_MODE_SINGLE_WORD",
+		10: "PAGE_SEG_MODE_CIRCLE_WORD",
+		11: "PAGE_SEG_MODE_SINGLE_CHAR",
+	}
+	PageSegMode_value = map[string]int32{
+		"PAGE_SEG_MODE_UNSPECIFIED":            0,
+		"PAGE_SEG_MODE_OSD_ONLY":               1,
+		"PAGE_SEG_MODE_AUTO_OSD":               2,
+		"PAGE_SEG_MODE_AUTO_ONLY":              3,
+		"PAGE_SEG_MODE_AUTO":                   4,
+		"PAGE_SEG_MODE_SINGLE_COLUMN":          5,
+		"PAGE_SEG_MODE_SINGLE_BLOCK_VERT_TEXT": 6,
+		"PAGE_SEG_MODE_SINGLE_BLOCK":           7,
+		"PAGE_SEG_MODE_SINGLE_LINE":            8,
+		"PAGE_SEG_MODE_SINGLE_WORD":            9,
+		"PAGE_SEG_MODE_CIRCLE_WORD":            10,
+		"PAGE_SEG_MODE_SINGLE_CHAR":            11,
+	}
+)
+
+func (x PageSegMode) Enum() *PageSegMode {
+	p := new(PageSegMode)
+	*p = x
+	return p
+}
+
+func (x PageSegMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PageSegMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_ocr_proto_enumTypes[0].Descriptor()
+}
+
+func (PageSegMode) Type() protoreflect.EnumType {
+	return &file_ocr_proto_enumTypes[0]
+}
+
+func (x PageSegMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PageSegMode.Descriptor instead.
+func (PageSegMode) EnumDescriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{0}
+}
+
+// Rectangle is an area of the image, as used by SetRectangle.
+type Rectangle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Left          int32                  `protobuf:"varint,1,opt,name=left,proto3" json:"left,omitempty"`
+	Top           int32                  `protobuf:"varint,2,opt,name=top,proto3" json:"top,omitempty"`
+	Width         int32                  `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rectangle) Reset() {
+	*x = Rectangle{}
+	mi := &file_ocr_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rectangle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rectangle) ProtoMessage() {}
+
+func (x *Rectangle) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rectangle.ProtoReflect.Descriptor instead.
+func (*Rectangle) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Rectangle) GetLeft() int32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *Rectangle) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+func (x *Rectangle) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Rectangle) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// BoundingBox is a box in image coordinates, the max point is exclusive like image.Rectangle.
+type BoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinX          int32                  `protobuf:"varint,1,opt,name=min_x,json=minX,proto3" json:"min_x,omitempty"`
+	MinY          int32                  `protobuf:"varint,2,opt,name=min_y,json=minY,proto3" json:"min_y,omitempty"`
+	MaxX          int32                  `protobuf:"varint,3,opt,name=max_x,json=maxX,proto3" json:"max_x,omitempty"`
+	MaxY          int32                  `protobuf:"varint,4,opt,name=max_y,json=maxY,proto3" json:"max_y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	mi := &file_ocr_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BoundingBox) GetMinX() int32 {
+	if x != nil {
+		return x.MinX
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMinY() int32 {
+	if x != nil {
+		return x.MinY
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxX() int32 {
+	if x != nil {
+		return x.MaxX
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxY() int32 {
+	if x != nil {
+		return x.MaxY
+	}
+	return 0
+}
+
+type RecognizeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// image is an encoded png, jpeg or gif image
+	Image []byte `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// language is one of the languages returned by ListLanguages, e.g. "eng" or "eng+nld". Empty selects the first.
+	Language    string      `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	PageSegMode PageSegMode `protobuf:"varint,3,opt,name=page_seg_mode,json=pageSegMode,proto3,enum=tesseract.v1.PageSegMode" json:"page_seg_mode,omitempty"`
+	// rectangle limits recognition to an area of the image
+	Rectangle *Rectangle `protobuf:"bytes,4,opt,name=rectangle,proto3" json:"rectangle,omitempty"`
+	// hocr requests the hOCR output in the response
+	Hocr          bool `protobuf:"varint,5,opt,name=hocr,proto3" json:"hocr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecognizeRequest) Reset() {
+	*x = RecognizeRequest{}
+	mi := &file_ocr_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecognizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecognizeRequest) ProtoMessage() {}
+
+func (x *RecognizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecognizeRequest.ProtoReflect.Descriptor instead.
+func (*RecognizeRequest) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecognizeRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *RecognizeRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *RecognizeRequest) GetPageSegMode() PageSegMode {
+	if x != nil {
+		return x.PageSegMode
+	}
+	return PageSegMode_PAGE_SEG_MODE_UNSPECIFIED
+}
+
+func (x *RecognizeRequest) GetRectangle() *Rectangle {
+	if x != nil {
+		return x.Rectangle
+	}
+	return nil
+}
+
+func (x *RecognizeRequest) GetHocr() bool {
+	if x != nil {
+		return x.Hocr
+	}
+	return false
+}
+
+type RecognizeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_number is the 0-based number of the page in the document, always 0 for Recognize
+	PageNumber int32  `protobuf:"varint,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
+	Text       string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// mean_confidence is the mean confidence of the words, between 0 and 100
+	MeanConfidence int32 `protobuf:"varint,3,opt,name=mean_confidence,json=meanConfidence,proto3" json:"mean_confidence,omitempty"`
+	Page           *Page `protobuf:"bytes,4,opt,name=page,proto3" json:"page,omitempty"`
+	// hocr is only set when it was requested. It is the ocr_page div of the page, not a complete hOCR document: put
+	// the pages of a document between the hOCR header and footer, e.g. those of tesseract.HOCRHeader and HOCRFooter.
+	Hocr          string `protobuf:"bytes,5,opt,name=hocr,proto3" json:"hocr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecognizeResponse) Reset() {
+	*x = RecognizeResponse{}
+	mi := &file_ocr_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecognizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecognizeResponse) ProtoMessage() {}
+
+func (x *RecognizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecognizeResponse.ProtoReflect.Descriptor instead.
+func (*RecognizeResponse) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecognizeResponse) GetPageNumber() int32 {
+	if x != nil {
+		return x.PageNumber
+	}
+	return 0
+}
+
+func (x *RecognizeResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *RecognizeResponse) GetMeanConfidence() int32 {
+	if x != nil {
+		return x.MeanConfidence
+	}
+	return 0
+}
+
+func (x *RecognizeResponse) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *RecognizeResponse) GetHocr() string {
+	if x != nil {
+		return x.Hocr
+	}
+	return ""
+}
+
+type Page struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Box           *BoundingBox           `protobuf:"bytes,1,opt,name=box,proto3" json:"box,omitempty"`
+	Blocks        []*Block               `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_ocr_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Page) GetBox() *BoundingBox {
+	if x != nil {
+		return x.Box
+	}
+	return nil
+}
+
+func (x *Page) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type Block struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Box           *BoundingBox           `protobuf:"bytes,1,opt,name=box,proto3" json:"box,omitempty"`
+	Paragraphs    []*Paragraph           `protobuf:"bytes,2,rep,name=paragraphs,proto3" json:"paragraphs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_ocr_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Block) GetBox() *BoundingBox {
+	if x != nil {
+		return x.Box
+	}
+	return nil
+}
+
+func (x *Block) GetParagraphs() []*Paragraph {
+	if x != nil {
+		return x.Paragraphs
+	}
+	return nil
+}
+
+type Paragraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Box           *BoundingBox           `protobuf:"bytes,1,opt,name=box,proto3" json:"box,omitempty"`
+	Lines         []*Line                `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Paragraph) Reset() {
+	*x = Paragraph{}
+	mi := &file_ocr_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Paragraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Paragraph) ProtoMessage() {}
+
+func (x *Paragraph) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Paragraph.ProtoReflect.Descriptor instead.
+func (*Paragraph) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Paragraph) GetBox() *BoundingBox {
+	if x != nil {
+		return x.Box
+	}
+	return nil
+}
+
+func (x *Paragraph) GetLines() []*Line {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type Line struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Box           *BoundingBox           `protobuf:"bytes,1,opt,name=box,proto3" json:"box,omitempty"`
+	Words         []*Word                `protobuf:"bytes,2,rep,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Line) Reset() {
+	*x = Line{}
+	mi := &file_ocr_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Line) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Line) ProtoMessage() {}
+
+func (x *Line) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Line.ProtoReflect.Descriptor instead.
+func (*Line) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Line) GetBox() *BoundingBox {
+	if x != nil {
+		return x.Box
+	}
+	return nil
+}
+
+func (x *Line) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type Word struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// confidence is between 0 and 100, or -1 when no confidence is available for the word
+	Confidence     int32        `protobuf:"varint,2,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Box            *BoundingBox `protobuf:"bytes,3,opt,name=box,proto3" json:"box,omitempty"`
+	FromDictionary bool         `protobuf:"varint,4,opt,name=from_dictionary,json=fromDictionary,proto3" json:"from_dictionary,omitempty"`
+	Numeric        bool         `protobuf:"varint,5,opt,name=numeric,proto3" json:"numeric,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Word) Reset() {
+	*x = Word{}
+	mi := &file_ocr_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Word) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Word) ProtoMessage() {}
+
+func (x *Word) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Word.ProtoReflect.Descriptor instead.
+func (*Word) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Word) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Word) GetConfidence() int32 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *Word) GetBox() *BoundingBox {
+	if x != nil {
+		return x.Box
+	}
+	return nil
+}
+
+func (x *Word) GetFromDictionary() bool {
+	if x != nil {
+		return x.FromDictionary
+	}
+	return false
+}
+
+func (x *Word) GetNumeric() bool {
+	if x != nil {
+		return x.Numeric
+	}
+	return false
+}
+
+type ListLanguagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLanguagesRequest) Reset() {
+	*x = ListLanguagesRequest{}
+	mi := &file_ocr_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLanguagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLanguagesRequest) ProtoMessage() {}
+
+func (x *ListLanguagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLanguagesRequest.ProtoReflect.Descriptor instead.
+func (*ListLanguagesRequest) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{9}
+}
+
+type ListLanguagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Languages     []string               `protobuf:"bytes,1,rep,name=languages,proto3" json:"languages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLanguagesResponse) Reset() {
+	*x = ListLanguagesResponse{}
+	mi := &file_ocr_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLanguagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLanguagesResponse) ProtoMessage() {}
+
+func (x *ListLanguagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLanguagesResponse.ProtoReflect.Descriptor instead.
+func (*ListLanguagesResponse) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListLanguagesResponse) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+var File_ocr_proto protoreflect.FileDescriptor
+
+const file_ocr_proto_rawDesc = "" +
+	"\n" +
+	"\tocr.proto\x12\ftesseract.v1\"_\n" +
+	"\tRectangle\x12\x12\n" +
+	"\x04left\x18\x01 \x01(\x05R\x04left\x12\x10\n" +
+	"\x03top\x18\x02 \x01(\x05R\x03top\x12\x14\n" +
+	"\x05width\x18\x03 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x04 \x01(\x05R\x06height\"a\n" +
+	"\vBoundingBox\x12\x13\n" +
+	"\x05min_x\x18\x01 \x01(\x05R\x04minX\x12\x13\n" +
+	"\x05min_y\x18\x02 \x01(\x05R\x04minY\x12\x13\n" +
+	"\x05max_x\x18\x03 \x01(\x05R\x04maxX\x12\x13\n" +
+	"\x05max_y\x18\x04 \x01(\x05R\x04maxY\"\xce\x01\n" +
+	"\x10RecognizeRequest\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\fR\x05image\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12=\n" +
+	"\rpage_seg_mode\x18\x03 \x01(\x0e2\x19.tesseract.v1.PageSegModeR\vpageSegMode\x125\n" +
+	"\trectangle\x18\x04 \x01(\v2\x17.tesseract.v1.RectangleR\trectangle\x12\x12\n" +
+	"\x04hocr\x18\x05 \x01(\bR\x04hocr\"\xad\x01\n" +
+	"\x11RecognizeResponse\x12\x1f\n" +
+	"\vpage_number\x18\x01 \x01(\x05R\n" +
+	"pageNumber\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12'\n" +
+	"\x0fmean_confidence\x18\x03 \x01(\x05R\x0emeanConfidence\x12&\n" +
+	"\x04page\x18\x04 \x01(\v2\x12.tesseract.v1.PageR\x04page\x12\x12\n" +
+	"\x04hocr\x18\x05 \x01(\tR\x04hocr\"`\n" +
+	"\x04Page\x12+\n" +
+	"\x03box\x18\x01 \x01(\v2\x19.tesseract.v1.BoundingBoxR\x03box\x12+\n" +
+	"\x06blocks\x18\x02 \x03(\v2\x13.tesseract.v1.BlockR\x06blocks\"m\n" +
+	"\x05Block\x12+\n" +
+	"\x03box\x18\x01 \x01(\v2\x19.tesseract.v1.BoundingBoxR\x03box\x127\n" +
+	"\n" +
+	"paragraphs\x18\x02 \x03(\v2\x17.tesseract.v1.ParagraphR\n" +
+	"paragraphs\"b\n" +
+	"\tParagraph\x12+\n" +
+	"\x03box\x18\x01 \x01(\v2\x19.tesseract.v1.BoundingBoxR\x03box\x12(\n" +
+	"\x05lines\x18\x02 \x03(\v2\x12.tesseract.v1.LineR\x05lines\"]\n" +
+	"\x04Line\x12+\n" +
+	"\x03box\x18\x01 \x01(\v2\x19.tesseract.v1.BoundingBoxR\x03box\x12(\n" +
+	"\x05words\x18\x02 \x03(\v2\x12.tesseract.v1.WordR\x05words\"\xaa\x01\n" +
+	"\x04Word\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x02 \x01(\x05R\n" +
+	"confidence\x12+\n" +
+	"\x03box\x18\x03 \x01(\v2\x19.tesseract.v1.BoundingBoxR\x03box\x12'\n" +
+	"\x0ffrom_dictionary\x18\x04 \x01(\bR\x0efromDictionary\x12\x18\n" +
+	"\anumeric\x18\x05 \x01(\bR\anumeric\"\x16\n" +
+	"\x14ListLanguagesRequest\"5\n" +
+	"\x15ListLanguagesResponse\x12\x1c\n" +
+	"\tlanguages\x18\x01 \x03(\tR\tlanguages*\x80\x03\n" +
+	"\vPageSegMode\x12\x1d\n" +
+	"\x19PAGE_SEG_MODE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16PAGE_SEG_MODE_OSD_ONLY\x10\x01\x12\x1a\n" +
+	"\x16PAGE_SEG_MODE_AUTO_OSD\x10\x02\x12\x1b\n" +
+	"\x17PAGE_SEG_MODE_AUTO_ONLY\x10\x03\x12\x16\n" +
+	"\x12PAGE_SEG_MODE_AUTO\x10\x04\x12\x1f\n" +
+	"\x1bPAGE_SEG_MODE_SINGLE_COLUMN\x10\x05\x12(\n" +
+	"$PAGE_SEG_MODE_SINGLE_BLOCK_VERT_TEXT\x10\x06\x12\x1e\n" +
+	"\x1aPAGE_SEG_MODE_SINGLE_BLOCK\x10\a\x12\x1d\n" +
+	"\x19PAGE_SEG_MODE_SINGLE_LINE\x10\b\x12\x1d\n" +
+	"\x19PAGE_SEG_MODE_SINGLE_WORD\x10\t\x12\x1d\n" +
+	"\x19PAGE_SEG_MODE_CIRCLE_WORD\x10\n" +
+	"\x12\x1d\n" +
+	"\x19PAGE_SEG_MODE_SINGLE_CHAR\x10\v2\x87\x02\n" +
+	"\x03OCR\x12L\n" +
+	"\tRecognize\x12\x1e.tesseract.v1.RecognizeRequest\x1a\x1f.tesseract.v1.RecognizeResponse\x12X\n" +
+	"\x11RecognizeDocument\x12\x1e.tesseract.v1.RecognizeRequest\x1a\x1f.tesseract.v1.RecognizeResponse(\x010\x01\x12X\n" +
+	"\rListLanguages\x12\".tesseract.v1.ListLanguagesRequest\x1a#.tesseract.v1.ListLanguagesResponseB1Z/gopkg.in/GeertJohan/go.tesseract.v1/tesseractpbb\x06proto3"
+
+var (
+	file_ocr_proto_rawDescOnce sync.Once
+	file_ocr_proto_rawDescData []byte
+)
+
+func file_ocr_proto_rawDescGZIP() []byte {
+	file_ocr_proto_rawDescOnce.Do(func() {
+		file_ocr_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ocr_proto_rawDesc), len(file_ocr_proto_rawDesc)))
+	})
+	return file_ocr_proto_rawDescData
+}
+
+var file_ocr_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ocr_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_ocr_proto_goTypes = []any{
+	(PageSegMode)(0),              // 0: tesseract.v1.PageSegMode
+	(*Rectangle)(nil),             // 1: tesseract.v1.Rectangle
+	(*BoundingBox)(nil),           // 2: tesseract.v1.BoundingBox
+	(*RecognizeRequest)(nil),      // 3: tesseract.v1.RecognizeRequest
+	(*RecognizeResponse)(nil),     // 4: tesseract.v1.RecognizeResponse
+	(*Page)(nil),                  // 5: tesseract.v1.Page
+	(*Block)(nil),                 // 6: tesseract.v1.Block
+	(*Paragraph)(nil),             // 7: tesseract.v1.Paragraph
+	(*Line)(nil),                  // 8: tesseract.v1.Line
+	(*Word)(nil),                  // 9: tesseract.v1.Word
+	(*ListLanguagesRequest)(nil),  // 10: tesseract.v1.ListLanguagesRequest
+	(*ListLanguagesResponse)(nil), // 11: tesseract.v1.ListLanguagesResponse
+}
+var file_ocr_proto_depIdxs = []int32{
+	0,  // 0: tesseract.v1.RecognizeRequest.page_seg_mode:type_name -> tesseract.v1.PageSegMode
+	1,  // 1: tesseract.v1.RecognizeRequest.rectangle:type_name -> tesseract.v1.Rectangle
+	5,  // 2: tesseract.v1.RecognizeResponse.page:type_name -> tesseract.v1.Page
+	2,  // 3: tesseract.v1.Page.box:type_name -> tesseract.v1.BoundingBox
+	6,  // 4: tesseract.v1.Page.blocks:type_name -> tesseract.v1.Block
+	2,  // 5: tesseract.v1.Block.box:type_name -> tesseract.v1.BoundingBox
+	7,  // 6: tesseract.v1.Block.paragraphs:type_name -> tesseract.v1.Paragraph
+	2,  // 7: tesseract.v1.Paragraph.box:type_name -> tesseract.v1.BoundingBox
+	8,  // 8: tesseract.v1.Paragraph.lines:type_name -> tesseract.v1.Line
+	2,  // 9: tesseract.v1.Line.box:type_name -> tesseract.v1.BoundingBox
+	9,  // 10: tesseract.v1.Line.words:type_name -> tesseract.v1.Word
+	2,  // 11: tesseract.v1.Word.box:type_name -> tesseract.v1.BoundingBox
+	3,  // 12: tesseract.v1.OCR.Recognize:input_type -> tesseract.v1.RecognizeRequest
+	3,  // 13: tesseract.v1.OCR.RecognizeDocument:input_type -> tesseract.v1.RecognizeRequest
+	10, // 14: tesseract.v1.OCR.ListLanguages:input_type -> tesseract.v1.ListLanguagesRequest
+	4,  // 15: tesseract.v1.OCR.Recognize:output_type -> tesseract.v1.RecognizeResponse
+	4,  // 16: tesseract.v1.OCR.RecognizeDocument:output_type -> tesseract.v1.RecognizeResponse
+	11, // 17: tesseract.v1.OCR.ListLanguages:output_type -> tesseract.v1.ListLanguagesResponse
+	15, // [15:18] is the sub-list for method output_type
+	12, // [12:15] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_ocr_proto_init() }
+func file_ocr_proto_init() {
+	if File_ocr_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ocr_proto_rawDesc), len(file_ocr_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ocr_proto_goTypes,
+		DependencyIndexes: file_ocr_proto_depIdxs,
+		EnumInfos:         file_ocr_proto_enumTypes,
+		MessageInfos:      file_ocr_proto_msgTypes,
+	}.Build()
+	File_ocr_proto = out.File
+	file_ocr_proto_goTypes = nil
+	file_ocr_proto_depIdxs = nil
+}
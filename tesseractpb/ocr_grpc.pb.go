@@ -0,0 +1,207 @@
+// OCR service backed by go.tesseract. The messages mirror the document model of the tesseract package: a page has
+// blocks of paragraphs of lines of words.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: ocr.proto
+
+package tesseractpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OCR_Recognize_FullMethodName         = "/tesseract.v1.OCR/Recognize"
+	OCR_RecognizeDocument_FullMethodName = "/tesseract.v1.OCR/RecognizeDocument"
+	OCR_ListLanguages_FullMethodName     = "/tesseract.v1.OCR/ListLanguages"
+)
+
+// OCRClient is the client API for OCR service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OCRClient interface {
+	// Recognize recognizes a single image.
+	Recognize(ctx context.Context, in *RecognizeRequest, opts ...grpc.CallOption) (*RecognizeResponse, error)
+	// RecognizeDocument recognizes the pages of a document. The client sends a request per page, the server sends a
+	// response per page in the same order. All pages are recognized with the same engine, so what tesseract's adaptive
+	// classifier learns on a page is used for the next pages. The language and page seg mode of the first request
+	// apply to the whole document.
+	RecognizeDocument(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RecognizeRequest, RecognizeResponse], error)
+	// ListLanguages returns the languages that can be requested.
+	ListLanguages(ctx context.Context, in *ListLanguagesRequest, opts ...grpc.CallOption) (*ListLanguagesResponse, error)
+}
+
+type oCRClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOCRClient(cc grpc.ClientConnInterface) OCRClient {
+	return &oCRClient{cc}
+}
+
+func (c *oCRClient) Recognize(ctx context.Context, in *RecognizeRequest, opts ...grpc.CallOption) (*RecognizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecognizeResponse)
+	err := c.cc.Invoke(ctx, OCR_Recognize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oCRClient) RecognizeDocument(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RecognizeRequest, RecognizeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OCR_ServiceDesc.Streams[0], OCR_RecognizeDocument_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RecognizeRequest, RecognizeResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OCR_RecognizeDocumentClient = grpc.BidiStreamingClient[RecognizeRequest, RecognizeResponse]
+
+func (c *oCRClient) ListLanguages(ctx context.Context, in *ListLanguagesRequest, opts ...grpc.CallOption) (*ListLanguagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLanguagesResponse)
+	err := c.cc.Invoke(ctx, OCR_ListLanguages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OCRServer is the server API for OCR service.
+// All implementations must embed UnimplementedOCRServer
+// for forward compatibility.
+type OCRServer interface {
+	// Recognize recognizes a single image.
+	Recognize(context.Context, *RecognizeRequest) (*RecognizeResponse, error)
+	// RecognizeDocument recognizes the pages of a document. The client sends a request per page, the server sends a
+	// response per page in the same order. All pages are recognized with the same engine, so what tesseract's adaptive
+	// classifier learns on a page is used for the next pages. The language and page seg mode of the first request
+	// apply to the whole document.
+	RecognizeDocument(grpc.BidiStreamingServer[RecognizeRequest, RecognizeResponse]) error
+	// ListLanguages returns the languages that can be requested.
+	ListLanguages(context.Context, *ListLanguagesRequest) (*ListLanguagesResponse, error)
+	mustEmbedUnimplementedOCRServer()
+}
+
+// UnimplementedOCRServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOCRServer struct{}
+
+func (UnimplementedOCRServer) Recognize(context.Context, *RecognizeRequest) (*RecognizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recognize not implemented")
+}
+func (UnimplementedOCRServer) RecognizeDocument(grpc.BidiStreamingServer[RecognizeRequest, RecognizeResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method RecognizeDocument not implemented")
+}
+func (UnimplementedOCRServer) ListLanguages(context.Context, *ListLanguagesRequest) (*ListLanguagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLanguages not implemented")
+}
+func (UnimplementedOCRServer) mustEmbedUnimplementedOCRServer() {}
+func (UnimplementedOCRServer) testEmbeddedByValue()             {}
+
+// UnsafeOCRServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OCRServer will
+// result in compilation errors.
+type UnsafeOCRServer interface {
+	mustEmbedUnimplementedOCRServer()
+}
+
+func RegisterOCRServer(s grpc.ServiceRegistrar, srv OCRServer) {
+	// If the following call pancis, it indicates UnimplementedOCRServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OCR_ServiceDesc, srv)
+}
+
+func _OCR_Recognize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecognizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OCRServer).Recognize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OCR_Recognize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OCRServer).Recognize(ctx, req.(*RecognizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OCR_RecognizeDocument_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(OCRServer).RecognizeDocument(&grpc.GenericServerStream[RecognizeRequest, RecognizeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OCR_RecognizeDocumentServer = grpc.BidiStreamingServer[RecognizeRequest, RecognizeResponse]
+
+func _OCR_ListLanguages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLanguagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OCRServer).ListLanguages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OCR_ListLanguages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OCRServer).ListLanguages(ctx, req.(*ListLanguagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OCR_ServiceDesc is the grpc.ServiceDesc for OCR service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OCR_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tesseract.v1.OCR",
+	HandlerType: (*OCRServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Recognize",
+			Handler:    _OCR_Recognize_Handler,
+		},
+		{
+			MethodName: "ListLanguages",
+			Handler:    _OCR_ListLanguages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RecognizeDocument",
+			Handler:       _OCR_RecognizeDocument_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "ocr.proto",
+}
@@ -0,0 +1,248 @@
+// Package tesseracttest provides a scripted fake tesseract.Engine, so code using go.tesseract can be unit-tested
+// without libtesseract. It builds with CGO_ENABLED=0.
+package tesseracttest
+
+import (
+	"errors"
+	"image"
+	"strings"
+
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+)
+
+// ErrNoDocuments is returned by Recognize when all documents of the script have been used.
+var ErrNoDocuments = errors.New("tesseracttest: no more documents")
+
+// Document is a canned recognition result.
+type Document struct {
+	Text string
+	HOCR string
+	// BoxText is the raw box text, as returned by (*tesseract.Tess).BoxTextRaw
+	BoxText    string
+	Confidence int
+	Words      []tesseract.Word
+	// Err is returned by Recognize instead of recognizing the document
+	Err error
+}
+
+// Engine is a scripted fake tesseract.Engine. Every image is "recognized" as the next document of the script.
+// The settings passed to Engine are recorded, so tests can check them.
+type Engine struct {
+	Documents []Document
+
+	// Calls holds the names of the methods that were called, in order
+	Calls       []string
+	Image       image.Image
+	Rectangle   image.Rectangle
+	PageSegMode tesseract.PageSegMode
+	Variables   map[string]string
+	Closed      bool
+
+	next    int
+	current *Document
+}
+
+var _ tesseract.Engine = (*Engine)(nil)
+
+// New creates an Engine that returns the documents in order.
+func New(documents ...Document) *Engine {
+	return &Engine{
+		Documents:   documents,
+		PageSegMode: tesseract.PSM_SINGLE_BLOCK,
+		Variables:   make(map[string]string),
+	}
+}
+
+// Remaining returns the number of documents that were not recognized yet.
+func (e *Engine) Remaining() int {
+	return len(e.Documents) - e.next
+}
+
+func (e *Engine) call(name string) {
+	e.Calls = append(e.Calls, name)
+}
+
+// SetImage implements tesseract.Engine
+func (e *Engine) SetImage(img image.Image) error {
+	e.call("SetImage")
+	if img.Bounds().Empty() {
+		return errors.New("empty image")
+	}
+	e.Image = img
+	e.Rectangle = img.Bounds()
+	e.current = nil
+	return nil
+}
+
+// SetRectangle implements tesseract.Engine
+func (e *Engine) SetRectangle(left, top, width, height int) {
+	e.call("SetRectangle")
+	e.Rectangle = image.Rect(left, top, left+width, top+height)
+	e.current = nil
+}
+
+// SetPageSegMode implements tesseract.Engine
+func (e *Engine) SetPageSegMode(psm tesseract.PageSegMode) {
+	e.call("SetPageSegMode")
+	e.PageSegMode = psm
+}
+
+// SetVariable implements tesseract.Engine, all variables are accepted.
+func (e *Engine) SetVariable(name, value string) error {
+	e.call("SetVariable")
+	e.Variables[name] = value
+	return nil
+}
+
+// Recognize implements tesseract.Engine, it takes the next document of the script.
+func (e *Engine) Recognize() error {
+	e.call("Recognize")
+	return e.recognize()
+}
+
+func (e *Engine) recognize() error {
+	if e.Image == nil {
+		return errors.New("no image set")
+	}
+	if e.next >= len(e.Documents) {
+		return ErrNoDocuments
+	}
+	doc := &e.Documents[e.next]
+	e.next++
+	if doc.Err != nil {
+		return doc.Err
+	}
+	e.current = doc
+	return nil
+}
+
+// document returns the current document, like Tess it recognizes the image first when that wasn't done yet.
+func (e *Engine) document() *Document {
+	if e.current == nil && e.recognize() != nil {
+		return &Document{}
+	}
+	return e.current
+}
+
+// Text implements tesseract.Engine
+func (e *Engine) Text() string {
+	e.call("Text")
+	return e.document().Text
+}
+
+// HOCRText implements tesseract.Engine
+func (e *Engine) HOCRText(pagenumber int) string {
+	e.call("HOCRText")
+	return e.document().HOCR
+}
+
+// BoxText implements tesseract.Engine
+func (e *Engine) BoxText(pagenumber int) (*tesseract.BoxText, error) {
+	e.call("BoxText")
+	return tesseract.ParseBoxText(e.document().BoxText)
+}
+
+// MeanTextConfidence implements tesseract.Engine
+func (e *Engine) MeanTextConfidence() int {
+	e.call("MeanTextConfidence")
+	return e.document().Confidence
+}
+
+// Words implements tesseract.Engine
+func (e *Engine) Words() ([]tesseract.Word, error) {
+	e.call("Words")
+	return append([]tesseract.Word{}, e.document().Words...), nil
+}
+
+// Results implements tesseract.Engine. The iterator walks the words of the document. At RIL_BLOCK, RIL_PARA and
+// RIL_TEXTLINE the whole document is a single element, RIL_SYMBOL is treated as RIL_WORD.
+func (e *Engine) Results() (tesseract.Iterator, error) {
+	e.call("Results")
+	if e.current == nil {
+		err := e.recognize()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &iterator{doc: e.current}, nil
+}
+
+// Close implements tesseract.Engine
+func (e *Engine) Close() {
+	e.call("Close")
+	e.Closed = true
+}
+
+// iterator is the tesseract.Iterator returned by Engine.Results
+type iterator struct {
+	doc  *Document
+	word int
+}
+
+func isWordLevel(level tesseract.PageIteratorLevel) bool {
+	return level == tesseract.RIL_WORD || level == tesseract.RIL_SYMBOL
+}
+
+func (it *iterator) Next(level tesseract.PageIteratorLevel) bool {
+	if !isWordLevel(level) || it.word+1 >= len(it.doc.Words) {
+		return false
+	}
+	it.word++
+	return true
+}
+
+func (it *iterator) Text(level tesseract.PageIteratorLevel) (string, error) {
+	if !isWordLevel(level) {
+		return it.doc.Text, nil
+	}
+	if it.word >= len(it.doc.Words) {
+		return "", errors.New("iterator is empty")
+	}
+	return it.doc.Words[it.word].Text, nil
+}
+
+func (it *iterator) Confidence(level tesseract.PageIteratorLevel) float32 {
+	if !isWordLevel(level) {
+		return float32(it.doc.Confidence)
+	}
+	if it.word >= len(it.doc.Words) {
+		return 0
+	}
+	return float32(it.doc.Words[it.word].Confidence)
+}
+
+func (it *iterator) BoundingBox(level tesseract.PageIteratorLevel) (image.Rectangle, bool) {
+	if !isWordLevel(level) {
+		var rect image.Rectangle
+		for _, w := range it.doc.Words {
+			rect = rect.Union(w.Box)
+		}
+		return rect, len(it.doc.Words) > 0
+	}
+	if it.word >= len(it.doc.Words) {
+		return image.Rectangle{}, false
+	}
+	return it.doc.Words[it.word].Box, true
+}
+
+func (it *iterator) WordIsFromDictionary() bool {
+	return it.word < len(it.doc.Words) && it.doc.Words[it.word].FromDictionary
+}
+
+func (it *iterator) WordIsNumeric() bool {
+	return it.word < len(it.doc.Words) && it.doc.Words[it.word].Numeric
+}
+
+// WordsFromText creates words from the space separated words in text, with the given confidence and without boxes.
+// It's a shortcut to fill Document.Words.
+func WordsFromText(text string, confidence int) []tesseract.Word {
+	fields := strings.Fields(text)
+	words := make([]tesseract.Word, 0, len(fields))
+	for _, f := range fields {
+		words = append(words, tesseract.Word{
+			Text:       f,
+			Confidence: confidence,
+		})
+	}
+	return words
+}
@@ -0,0 +1,150 @@
+package tesseracttest
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"gopkg.in/GeertJohan/go.tesseract.v1"
+)
+
+func TestEngineScript(t *testing.T) {
+	errFailed := errors.New("failed")
+	e := New(
+		Document{Text: "one\n", Confidence: 80},
+		Document{Err: errFailed},
+		Document{Text: "three\n"},
+	)
+	if e.Recognize() == nil {
+		t.Error("expected an error recognizing without image")
+	}
+
+	err := e.SetImage(image.NewGray(image.Rect(0, 0, 100, 50)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Rectangle != image.Rect(0, 0, 100, 50) {
+		t.Errorf("expected the rectangle of the image, got %v", e.Rectangle)
+	}
+	err = e.Recognize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Text() != "one\n" || e.MeanTextConfidence() != 80 {
+		t.Errorf("unexpected result: %q %d", e.Text(), e.MeanTextConfidence())
+	}
+	if e.Recognize() != errFailed {
+		t.Error("expected the error of the second document")
+	}
+	// like Tess, the results of an image that wasn't recognized yet recognize it first
+	e.SetRectangle(10, 5, 20, 10)
+	if e.Text() != "three\n" {
+		t.Errorf("expected the third document, got %q", e.Text())
+	}
+	if e.Remaining() != 0 {
+		t.Errorf("expected no remaining documents, got %d", e.Remaining())
+	}
+	if e.Recognize() != ErrNoDocuments {
+		t.Error("expected ErrNoDocuments")
+	}
+}
+
+func TestEngineRecordsSettings(t *testing.T) {
+	e := New(Document{})
+	if e.SetImage(image.NewGray(image.Rectangle{})) == nil {
+		t.Error("expected an error for an empty image")
+	}
+	e.SetImage(image.NewGray(image.Rect(0, 0, 100, 50)))
+	e.SetRectangle(10, 5, 20, 10)
+	e.SetPageSegMode(tesseract.PSM_SINGLE_LINE)
+	e.SetVariable("tessedit_char_whitelist", "0123456789")
+	e.Close()
+
+	if e.Rectangle != image.Rect(10, 5, 30, 15) {
+		t.Errorf("unexpected rectangle: %v", e.Rectangle)
+	}
+	if e.PageSegMode != tesseract.PSM_SINGLE_LINE {
+		t.Errorf("unexpected page seg mode: %s", e.PageSegMode)
+	}
+	if e.Variables["tessedit_char_whitelist"] != "0123456789" {
+		t.Errorf("unexpected variables: %v", e.Variables)
+	}
+	if !e.Closed {
+		t.Error("expected the engine to be closed")
+	}
+	expected := []string{"SetImage", "SetImage", "SetRectangle", "SetPageSegMode", "SetVariable", "Close"}
+	if len(e.Calls) != len(expected) {
+		t.Fatalf("expected calls %v, got %v", expected, e.Calls)
+	}
+	for i, call := range e.Calls {
+		if call != expected[i] {
+			t.Errorf("expected calls %v, got %v", expected, e.Calls)
+			break
+		}
+	}
+}
+
+func TestEngineResults(t *testing.T) {
+	words := WordsFromText("hello world", 90)
+	words[0].Box = image.Rect(0, 0, 40, 10)
+	words[1].Box = image.Rect(50, 0, 100, 12)
+	e := New(Document{Text: "hello world\n", Confidence: 85, Words: words})
+	e.SetImage(image.NewGray(image.Rect(0, 0, 100, 50)))
+
+	it, err := e.Results()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text, _ := it.Text(tesseract.RIL_TEXTLINE); text != "hello world\n" {
+		t.Errorf("expected the document text for a line, got %q", text)
+	}
+	if box, ok := it.BoundingBox(tesseract.RIL_BLOCK); !ok || box != image.Rect(0, 0, 100, 12) {
+		t.Errorf("expected the union of the word boxes for a block, got %v", box)
+	}
+	if it.Confidence(tesseract.RIL_PARA) != 85 {
+		t.Errorf("expected the document confidence for a paragraph, got %f", it.Confidence(tesseract.RIL_PARA))
+	}
+
+	var texts []string
+	for {
+		text, err := it.Text(tesseract.RIL_WORD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		texts = append(texts, text)
+		if it.Confidence(tesseract.RIL_WORD) != 90 {
+			t.Errorf("%s: unexpected confidence %f", text, it.Confidence(tesseract.RIL_WORD))
+		}
+		if !it.Next(tesseract.RIL_WORD) {
+			break
+		}
+	}
+	if len(texts) != 2 || texts[0] != "hello" || texts[1] != "world" {
+		t.Errorf("unexpected words: %v", texts)
+	}
+	if box, _ := it.BoundingBox(tesseract.RIL_WORD); box != words[1].Box {
+		t.Errorf("unexpected box of the last word: %v", box)
+	}
+	if it.Next(tesseract.RIL_TEXTLINE) {
+		t.Error("expected a single line")
+	}
+}
+
+func TestEngineEmptyDocument(t *testing.T) {
+	e := New(Document{})
+	e.SetImage(image.NewGray(image.Rect(0, 0, 100, 50)))
+	it, err := e.Results()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := it.Text(tesseract.RIL_WORD); err == nil {
+		t.Error("expected an error for the text of a word in an empty document")
+	}
+	if _, ok := it.BoundingBox(tesseract.RIL_BLOCK); ok {
+		t.Error("expected no bounding box for an empty document")
+	}
+	words, err := e.Words()
+	if err != nil || len(words) != 0 {
+		t.Errorf("expected no words, got %v, %v", words, err)
+	}
+}
@@ -41,9 +41,9 @@ func main() {
 	t.SetPageSegMode(tesseract.PSM_AUTO_OSD)
 
 	// setup a whitelist of all basic ascii
-	err = t.SetVariable("tessedit_char_whitelist", ` !"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_abcdefghijklmnopqrstuvwxyz{|}~`+"`")
+	err = t.SetWhitelist(tesseract.ASCIIPrintable)
 	if err != nil {
-		log.Fatalf("Failed to SetVariable: %s\n", err)
+		log.Fatalf("Failed to SetWhitelist: %s\n", err)
 	}
 
 	// set the image to the tesseract instance
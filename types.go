@@ -0,0 +1,168 @@
+package tesseract
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// typedef enum TessOcrEngineMode { OEM_TESSERACT_ONLY, OEM_CUBE_ONLY, OEM_TESSERACT_CUBE_COMBINED, OEM_DEFAULT } TessOcrEngineMode;
+// With tesseract 4.0 and later, mode 1 and 2 select the LSTM engine and LSTM combined with the legacy engine.
+type EngineMode int
+
+const (
+	OEM_TESSERACT_ONLY EngineMode = iota
+	OEM_CUBE_ONLY
+	OEM_TESSERACT_CUBE_COMBINED
+	OEM_DEFAULT
+)
+
+// typedef enum TessPageSegMode { PSM_OSD_ONLY, PSM_AUTO_OSD, PSM_AUTO_ONLY, PSM_AUTO, PSM_SINGLE_COLUMN, PSM_SINGLE_BLOCK_VERT_TEXT, PSM_SINGLE_BLOCK, PSM_SINGLE_LINE, PSM_SINGLE_WORD, PSM_CIRCLE_WORD, PSM_SINGLE_CHAR, PSM_COUNT } TessPageSegMode;
+type PageSegMode int
+
+const (
+	PSM_OSD_ONLY PageSegMode = iota
+	PSM_AUTO_OSD
+	PSM_AUTO_ONLY
+	PSM_AUTO
+	PSM_SINGLE_COLUMN
+	PSM_SINGLE_BLOCK_VERT_TEXT
+	PSM_SINGLE_BLOCK
+	PSM_SINGLE_LINE
+	PSM_SINGLE_WORD
+	PSM_CIRCLE_WORD
+	PSM_SINGLE_CHAR
+	PSM_COUNT
+)
+
+// typedef enum TessPageIteratorLevel { RIL_BLOCK, RIL_PARA, RIL_TEXTLINE, RIL_WORD, RIL_SYMBOL} TessPageIteratorLevel;
+type PageIteratorLevel int
+
+const (
+	RIL_BLOCK PageIteratorLevel = iota
+	RIL_PARA
+	RIL_TEXTLINE
+	RIL_WORD
+	RIL_SYMBOL
+)
+
+// typedef enum TessOrientation { ORIENTATION_PAGE_UP, ORIENTATION_PAGE_RIGHT, ORIENTATION_PAGE_DOWN, ORIENTATION_PAGE_LEFT } TessOrientation;
+type PageOrientation int
+
+const (
+	ORIENTATION_PAGE_UP PageOrientation = iota
+	ORIENTATION_PAGE_RIGHT
+	ORIENTATION_PAGE_DOWN
+	ORIENTATION_PAGE_LEFT
+)
+
+// typedef enum TessWritingDirection { WRITING_DIRECTION_LEFT_TO_RIGHT, WRITING_DIRECTION_RIGHT_TO_LEFT, WRITING_DIRECTION_TOP_TO_BOTTOM } TessWritingDirection;
+type WritingDirection int
+
+const (
+	WRITING_DIRECTION_LEFT_TO_RIGHT WritingDirection = iota
+	WRITING_DIRECTION_RIGHT_TO_LEFT
+	WRITING_DIRECTION_TOP_TO_BOTTOM
+)
+
+// typedef enum TessTextlineOrder { TEXTLINE_ORDER_LEFT_TO_RIGHT, TEXTLINE_ORDER_RIGHT_TO_LEFT, TEXTLINE_ORDER_TOP_TO_BOTTOM } TessTextlineOrder;
+type TextlineOrder int
+
+const (
+	TEXTLINE_ORDER_LEFT_TO_RIGHT TextlineOrder = iota
+	TEXTLINE_ORDER_RIGHT_TO_LEFT
+	TEXTLINE_ORDER_TOP_TO_BOTTOM
+)
+
+// TODO: make this: `type BoxText []BoxCharacter` ?
+type BoxText struct {
+	Characters []BoxCharacter
+}
+
+type BoxCharacter struct {
+	Character  rune
+	StartX     uint32
+	StartY     uint32
+	EndX       uint32
+	EndY       uint32
+	Pagenumber uint32
+}
+
+// ParseBoxText parses box text as returned by BoxTextRaw or the tesseract command with the makebox config.
+func ParseBoxText(text string) (*BoxText, error) {
+	textBuffer := bytes.NewBufferString(text)
+
+	bt := &BoxText{
+		Characters: make([]BoxCharacter, 0, len(text)),
+	}
+	for {
+		line, err := textBuffer.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return bt, nil
+			}
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\n")
+		fields := strings.Split(line, " ")
+		if len(fields) != 6 {
+			f := strconv.Itoa(len(fields))
+			return nil, errors.New("unexpected BoxText format (Length != 6) Length is: " + f)
+		}
+		if utf8.RuneCountInString(fields[0]) != 1 {
+			return nil, errors.New("unexpected BoxText format (RuneCount error): " + fields[0])
+		}
+
+		sx, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		sy, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		ex, err := strconv.ParseUint(fields[3], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		ey, err := strconv.ParseUint(fields[4], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		pgnr, err := strconv.ParseUint(fields[5], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		bt.Characters = append(bt.Characters, BoxCharacter{
+			Character:  rune(fields[0][0]),
+			StartX:     uint32(sx),
+			StartY:     uint32(sy),
+			EndX:       uint32(ex),
+			EndY:       uint32(ey),
+			Pagenumber: uint32(pgnr),
+		})
+	}
+}
+
+// Word is a single recognized word
+type Word struct {
+	Text string `json:"text"`
+	// Confidence is between 0 and 100, or -1 when no confidence is available for the word
+	Confidence int `json:"confidence"`
+	// Box is the bounding box of the word in image coordinates
+	Box image.Rectangle `json:"box"`
+	// FromDictionary is true when tesseract found the word in a dictionary
+	FromDictionary bool `json:"fromDictionary"`
+	// Numeric is true when the word is numeric
+	Numeric bool `json:"numeric"`
+}
+
+// Choice is an alternative recognition result for a symbol
+type Choice struct {
+	Text       string
+	Confidence float32
+}
@@ -0,0 +1,78 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NewTessWithUserWords creates a new tesseract instance with given engine mode that knows about the given domain
+// specific words and patterns. Words are added to the dictionary. Patterns describe words such as part numbers, e.g.
+// `\d\d\d-\A\A`, where \c is any character, \d any digit, \n any alphanumeric, \p any punctuation, \a any lowercase and
+// \A any uppercase letter. A pattern element followed by \* may repeat.
+//
+// The words and patterns are written to a temporary directory that is removed after initialization.
+// This requires a tesseract version that supports the user_words_file and user_patterns_file variables.
+func NewTessWithUserWords(datapath string, language string, oem EngineMode, words []string, patterns []string) (*Tess, error) {
+	dir, err := ioutil.TempDir("", "go.tesseract")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	configFilename, err := writeUserWordsConfig(dir, words, patterns)
+	if err != nil {
+		return nil, err
+	}
+	if configFilename == "" {
+		return newTess(datapath, language, oem, nil)
+	}
+	return newTess(datapath, language, oem, []string{configFilename})
+}
+
+// writeUserWordsConfig writes the words and patterns to files in dir, and a config file that sets them. It returns the
+// name of the config file, or "" when there are no words and patterns.
+func writeUserWordsConfig(dir string, words []string, patterns []string) (string, error) {
+	config := ""
+	if len(words) > 0 {
+		filename := filepath.Join(dir, "user-words")
+		err := writeLines(filename, words)
+		if err != nil {
+			return "", err
+		}
+		config += "user_words_file " + filename + "\n"
+	}
+	if len(patterns) > 0 {
+		filename := filepath.Join(dir, "user-patterns")
+		err := writeLines(filename, patterns)
+		if err != nil {
+			return "", err
+		}
+		config += "user_patterns_file " + filename + "\n"
+	}
+	if config == "" {
+		return "", nil
+	}
+
+	configFilename := filepath.Join(dir, "user-words.config")
+	err := ioutil.WriteFile(configFilename, []byte(config), 0600)
+	if err != nil {
+		return "", err
+	}
+	return configFilename, nil
+}
+
+// writeLines writes one line per entry to a file, entries may not contain newlines.
+func writeLines(filename string, lines []string) error {
+	for _, line := range lines {
+		if strings.ContainsAny(line, "\r\n") {
+			return errors.New("invalid entry, contains a newline: " + line)
+		}
+	}
+	return ioutil.WriteFile(filename, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+}
@@ -0,0 +1,66 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteUserWordsConfig(t *testing.T) {
+	dir := t.TempDir()
+	configFilename, err := writeUserWordsConfig(dir, []string{"gotess", "leptonica"}, []string{`\d\d\d-\A\A`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"user-words.config": "user_words_file " + filepath.Join(dir, "user-words") + "\n" +
+			"user_patterns_file " + filepath.Join(dir, "user-patterns") + "\n",
+		"user-words":    "gotess\nleptonica\n",
+		"user-patterns": `\d\d\d-\A\A` + "\n",
+	}
+	if configFilename != filepath.Join(dir, "user-words.config") {
+		t.Errorf("unexpected config file %s", configFilename)
+	}
+	for name, content := range expected {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if string(data) != content {
+			t.Errorf("%s: expected %q, got %q", name, content, data)
+		}
+	}
+
+	configFilename, err = writeUserWordsConfig(t.TempDir(), nil, nil)
+	if err != nil || configFilename != "" {
+		t.Errorf("expected no config file without words and patterns, got %q, %v", configFilename, err)
+	}
+	_, err = writeUserWordsConfig(t.TempDir(), []string{"two\nlines"}, nil)
+	if err == nil {
+		t.Error("expected an error for a word with a newline")
+	}
+}
+
+func TestNewTessWithUserWordsCleanup(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	// initialization fails for a missing datapath, the temporary files must be removed anyway
+	tess, err := NewTessWithUserWords(filepath.Join(tmp, "missing"), "eng", OEM_DEFAULT, []string{"gotess"}, nil)
+	if err == nil {
+		tess.Close()
+		t.Fatal("expected an error for a missing datapath")
+	}
+	infos, err := ioutil.ReadDir(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 0 {
+		t.Errorf("expected the temporary directory to be removed, found %s", infos[0].Name())
+	}
+}
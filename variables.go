@@ -0,0 +1,142 @@
+package tesseract
+
+import (
+	"sort"
+	"strconv"
+)
+
+//go:generate go run ./internal/genvariables
+
+// VariableType is the type of a tesseract variable.
+type VariableType int
+
+const (
+	VariableInt VariableType = iota
+	VariableBool
+	VariableDouble
+	VariableString
+)
+
+// String returns the name of the type as used by tesseract
+func (vt VariableType) String() string {
+	switch vt {
+	case VariableInt:
+		return "int"
+	case VariableBool:
+		return "bool"
+	case VariableDouble:
+		return "double"
+	case VariableString:
+		return "string"
+	}
+	return "VariableType(" + strconv.Itoa(int(vt)) + ")"
+}
+
+// VariableInfo describes a tesseract variable.
+type VariableInfo struct {
+	Name string
+	Type VariableType
+	// Default is the default value in the oldest tesseract version the registry was generated from that has the
+	// variable. It may differ in other versions.
+	Default string
+	// InitOnly variables can only be set while initializing, from a config file passed to NewTessWithConfigs.
+	// Setting them after NewTess has no effect. Tesseract doesn't report this, it comes from a hand-maintained list of
+	// the variables declared with the *_INIT_MEMBER macros in the tesseract sources, which may be incomplete.
+	InitOnly    bool
+	Description string
+	// Since is the tesseract version that introduced the variable. It's empty for variables that exist in the oldest
+	// version the registry was generated from, and for all variables while RegistryVersions is empty.
+	Since string
+}
+
+// RegistryVersions returns the tesseract versions the registry of KnownVariables was generated from, oldest first.
+// When it's empty, the registry is a hand-written list that wasn't checked against a tesseract version: the names,
+// types and defaults may not match the installed tesseract, and Since is unknown.
+func RegistryVersions() []string {
+	return append([]string{}, registryVersions...)
+}
+
+// KnownVariables returns the registry of tesseract variables, sorted by name.
+func KnownVariables() []VariableInfo {
+	return append([]VariableInfo{}, knownVariables...)
+}
+
+// LookupVariable returns information about a known tesseract variable.
+func LookupVariable(name string) (VariableInfo, bool) {
+	i := sort.Search(len(knownVariables), func(i int) bool { return knownVariables[i].Name >= name })
+	if i < len(knownVariables) && knownVariables[i].Name == name {
+		return knownVariables[i], true
+	}
+	return VariableInfo{}, false
+}
+
+// InitOnlyError is returned when setting a variable that can only be set during initialization.
+type InitOnlyError struct {
+	Name string
+}
+
+// Error implements the error interface
+func (e *InitOnlyError) Error() string {
+	return "variable " + e.Name + " can only be set during initialization"
+}
+
+// UnknownVariableError is returned when setting a variable that tesseract doesn't know.
+type UnknownVariableError struct {
+	Name string
+	Type VariableType
+	// Suggestion is the name of a known variable that is close to Name, if any
+	Suggestion string
+}
+
+// Error implements the error interface
+func (e *UnknownVariableError) Error() string {
+	msg := "unknown " + e.Type.String() + " variable " + e.Name
+	if e.Suggestion != "" {
+		msg += ", did you mean " + e.Suggestion + "?"
+	}
+	return msg
+}
+
+// suggestVariable returns the known variable name closest to name, or "" when there is no close match.
+func suggestVariable(name string) string {
+	best, bestDistance := "", 4
+	for _, v := range knownVariables {
+		distance := levenshtein(name, v.Name)
+		if distance < bestDistance {
+			best, bestDistance = v.Name, distance
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,72 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import "strconv"
+
+// checkVariable validates that name is a variable of given type that can be set after initialization.
+func (t *Tess) checkVariable(name string, vt VariableType) error {
+	if info, ok := LookupVariable(name); ok && info.InitOnly {
+		return &InitOnlyError{Name: name}
+	}
+
+	var ok bool
+	switch vt {
+	case VariableInt:
+		_, ok = t.IntVariable(name)
+	case VariableBool:
+		_, ok = t.BoolVariable(name)
+	case VariableDouble:
+		_, ok = t.DoubleVariable(name)
+	case VariableString:
+		_, ok = t.StringVariable(name)
+	}
+	if !ok {
+		return &UnknownVariableError{
+			Name:       name,
+			Type:       vt,
+			Suggestion: suggestVariable(name),
+		}
+	}
+	return nil
+}
+
+// SetIntVariable sets an int variable, after checking that tesseract has an int variable with that name that is not init-only.
+func (t *Tess) SetIntVariable(name string, value int) error {
+	err := t.checkVariable(name, VariableInt)
+	if err != nil {
+		return err
+	}
+	return t.SetVariable(name, strconv.Itoa(value))
+}
+
+// SetBoolVariable sets a bool variable, after checking that tesseract has a bool variable with that name that is not init-only.
+func (t *Tess) SetBoolVariable(name string, value bool) error {
+	err := t.checkVariable(name, VariableBool)
+	if err != nil {
+		return err
+	}
+	if value {
+		return t.SetVariable(name, "1")
+	}
+	return t.SetVariable(name, "0")
+}
+
+// SetDoubleVariable sets a double variable, after checking that tesseract has a double variable with that name that is not init-only.
+func (t *Tess) SetDoubleVariable(name string, value float64) error {
+	err := t.checkVariable(name, VariableDouble)
+	if err != nil {
+		return err
+	}
+	return t.SetVariable(name, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// SetStringVariable sets a string variable, after checking that tesseract has a string variable with that name that is not init-only.
+func (t *Tess) SetStringVariable(name string, value string) error {
+	err := t.checkVariable(name, VariableString)
+	if err != nil {
+		return err
+	}
+	return t.SetVariable(name, value)
+}
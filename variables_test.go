@@ -0,0 +1,21 @@
+package tesseract
+
+import "testing"
+
+// TestKnownVariablesSorted checks the order LookupVariable relies on.
+func TestKnownVariablesSorted(t *testing.T) {
+	for i := 1; i < len(knownVariables); i++ {
+		if knownVariables[i-1].Name >= knownVariables[i].Name {
+			t.Errorf("%s is sorted before %s", knownVariables[i-1].Name, knownVariables[i].Name)
+		}
+	}
+	for _, v := range knownVariables {
+		info, ok := LookupVariable(v.Name)
+		if !ok || info != v {
+			t.Errorf("LookupVariable(%q): expected %v, got %v", v.Name, v, info)
+		}
+	}
+	if _, ok := LookupVariable("tessedit_char_whitlist"); ok {
+		t.Error("found a variable that doesn't exist")
+	}
+}
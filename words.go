@@ -0,0 +1,23 @@
+package tesseract
+
+import (
+	"strings"
+	"unicode"
+)
+
+// suspiciousWords returns the words that are not from a dictionary, not numeric and for which isValidWord returns
+// false without their leading and trailing punctuation. Words that are only punctuation are not suspicious.
+func suspiciousWords(words []Word, isValidWord func(word string) bool) []Word {
+	suspicious := make([]Word, 0)
+	for _, word := range words {
+		if word.FromDictionary || word.Numeric {
+			continue
+		}
+		text := strings.TrimFunc(word.Text, unicode.IsPunct)
+		if text == "" || isValidWord(text) {
+			continue
+		}
+		suspicious = append(suspicious, word)
+	}
+	return suspicious
+}
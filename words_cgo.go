@@ -0,0 +1,65 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+// Words returns the recognized words with their confidence.
+// When the image has not been recognized yet, this will run recognition first.
+func (t *Tess) Words() ([]Word, error) {
+	it, err := t.recognizedIterator()
+	if err != nil {
+		return nil, err
+	}
+
+	words := make([]Word, 0)
+	for {
+		text, err := it.Text(RIL_WORD)
+		if err != nil {
+			// empty page
+			break
+		}
+		words = append(words, it.word(text))
+
+		if !it.Next(RIL_WORD) {
+			break
+		}
+	}
+	return words, nil
+}
+
+// recognizedIterator returns an iterator over the results, the image is recognized first when that wasn't done yet.
+func (t *Tess) recognizedIterator() (*ResultIterator, error) {
+	it, err := t.Iterator()
+	if err == nil {
+		return it, nil
+	}
+	err = t.Recognize()
+	if err != nil {
+		return nil, err
+	}
+	return t.Iterator()
+}
+
+// word returns the word at the position of the iterator. The confidence is taken from the same position, so it can't
+// get out of step with the words.
+func (r *ResultIterator) word(text string) Word {
+	word := Word{
+		Text:           text,
+		Confidence:     int(r.Confidence(RIL_WORD)),
+		FromDictionary: r.WordIsFromDictionary(),
+		Numeric:        r.WordIsNumeric(),
+	}
+	word.Box, _ = r.BoundingBox(RIL_WORD)
+	return word
+}
+
+// SuspiciousWords returns the recognized words that are not from a dictionary, not numeric and not valid dictionary
+// words (see IsValidWord) without their leading and trailing punctuation. These are the words a reviewer should look
+// at first.
+func (t *Tess) SuspiciousWords() ([]Word, error) {
+	words, err := t.Words()
+	if err != nil {
+		return nil, err
+	}
+	return suspiciousWords(words, t.IsValidWord), nil
+}
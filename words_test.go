@@ -0,0 +1,37 @@
+package tesseract
+
+import (
+	"testing"
+)
+
+func TestSuspiciousWords(t *testing.T) {
+	dictionary := map[string]bool{"Hello": true, "world": true}
+	words := []Word{
+		{Text: "Hello,"},
+		{Text: "(world)"},
+		{Text: "wrold"},
+		{Text: "\"wrold.\""},
+		{Text: "Tesseract", FromDictionary: true},
+		{Text: "1234", Numeric: true},
+		{Text: "--"},
+	}
+	var checked []string
+	suspicious := suspiciousWords(words, func(word string) bool {
+		checked = append(checked, word)
+		return dictionary[word]
+	})
+
+	if len(suspicious) != 2 || suspicious[0].Text != "wrold" || suspicious[1].Text != "\"wrold.\"" {
+		t.Errorf("expected wrold and \"wrold.\", got %v", suspicious)
+	}
+	expected := []string{"Hello", "world", "wrold", "wrold"}
+	if len(checked) != len(expected) {
+		t.Fatalf("expected %v to be checked, got %v", expected, checked)
+	}
+	for i, word := range checked {
+		if word != expected[i] {
+			t.Errorf("expected %v to be checked, got %v", expected, checked)
+			break
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package tesseract
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// Zone is a named area of a fixed-layout form, with the settings used to recognize it.
+// Settings that are not set are left as they are on the Tess.
+type Zone struct {
+	Name        string            `json:"name" yaml:"name"`
+	Rectangle   Rectangle         `json:"rectangle" yaml:"rectangle"`
+	PageSegMode *PageSegMode      `json:"pageSegMode,omitempty" yaml:"pageSegMode,omitempty"`
+	Whitelist   string            `json:"whitelist,omitempty" yaml:"whitelist,omitempty"`
+	Blacklist   string            `json:"blacklist,omitempty" yaml:"blacklist,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+// profile returns a profile with the settings of the zone.
+func (z *Zone) profile(dpi int) *Profile {
+	rect := z.Rectangle
+	return &Profile{
+		Name:        z.Name,
+		PageSegMode: z.PageSegMode,
+		Variables:   z.Variables,
+		Whitelist:   z.Whitelist,
+		Blacklist:   z.Blacklist,
+		DPI:         dpi,
+		Rectangle:   &rect,
+	}
+}
+
+// ZoneTemplate is a list of zones of a fixed-layout form, e.g. the amount and address fields of a payment slip.
+type ZoneTemplate struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// DPI is the resolution of the form images, optional
+	DPI   int    `json:"dpi,omitempty" yaml:"dpi,omitempty"`
+	Zones []Zone `json:"zones" yaml:"zones"`
+}
+
+// ZoneResult is the recognition result of a single zone.
+type ZoneResult struct {
+	// Text is the recognized text, without leading and trailing whitespace
+	Text string
+	// Confidence is the mean confidence of the words in the zone, between 0 and 100
+	Confidence int
+	Words      []Word
+}
+
+// LoadZoneTemplate reads a zone template from a .json, .yaml or .yml file.
+func LoadZoneTemplate(filename string) (*ZoneTemplate, error) {
+	zt := &ZoneTemplate{}
+	err := loadFile(filename, zt)
+	if err != nil {
+		return nil, err
+	}
+	if zt.Name == "" {
+		zt.Name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	err = zt.Validate()
+	if err != nil {
+		return nil, errors.New(filename + ": " + err.Error())
+	}
+	return zt, nil
+}
+
+// Validate checks that all zones have a unique name and a non-empty rectangle.
+func (zt *ZoneTemplate) Validate() error {
+	names := make(map[string]bool, len(zt.Zones))
+	for _, z := range zt.Zones {
+		if z.Name == "" {
+			return errors.New("zone without name")
+		}
+		if names[z.Name] {
+			return errors.New("duplicate zone " + z.Name)
+		}
+		names[z.Name] = true
+		if z.Rectangle.Width <= 0 || z.Rectangle.Height <= 0 {
+			return errors.New("zone " + z.Name + " has an empty rectangle")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+//go:build cgo
+// +build cgo
+
+package tesseract
+
+import (
+	"errors"
+	"strings"
+)
+
+// Apply recognizes all zones of the image set with SetImagePix or SetImage, and returns the results by zone name.
+// The settings of each zone are reverted after recognizing it, so the Tess is left as it was.
+func (zt *ZoneTemplate) Apply(t *Tess) (map[string]ZoneResult, error) {
+	err := zt.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]ZoneResult, len(zt.Zones))
+	for i := range zt.Zones {
+		z := &zt.Zones[i]
+		result, err := z.recognize(t, zt.DPI)
+		if err != nil {
+			return nil, errors.New("zone " + z.Name + ": " + err.Error())
+		}
+		results[z.Name] = result
+	}
+	return results, nil
+}
+
+// recognize applies the settings of the zone, recognizes it and reverts the settings.
+func (z *Zone) recognize(t *Tess, dpi int) (ZoneResult, error) {
+	applied, err := z.profile(dpi).Apply(t)
+	if err != nil {
+		return ZoneResult{}, err
+	}
+	defer applied.Revert()
+
+	err = t.Recognize()
+	if err != nil {
+		return ZoneResult{}, err
+	}
+	words, err := t.Words()
+	if err != nil {
+		return ZoneResult{}, err
+	}
+	return ZoneResult{
+		Text:       strings.TrimSpace(t.Text()),
+		Confidence: t.MeanTextConfidence(),
+		Words:      words,
+	}, nil
+}